@@ -1,5 +1,7 @@
 package constants
 
+import "time"
+
 const (
 	Port       = ":8080"
 	APIVersion = "v1"
@@ -10,12 +12,42 @@ const (
 	Dashboards    = BaseAPI + "/dashboards/"
 	Notifications = BaseAPI + "/notifications/"
 	Status        = BaseAPI + "/status/"
+	Aliases       = BaseAPI + "/aliases/"
+	Countries     = BaseAPI + "/countries/"
+	Currencies    = BaseAPI + "/currencies/"
+	Deliveries    = BaseAPI + "/deliveries/"
+
+	// Sub-resources and query parameters of the local endpoints
+	CompareSegment      = "compare"       // /dashboards/compare?ids=a,b,c
+	SuggestSegment      = "suggest"       // /countries/suggest?q=nor
+	BadgeSegment        = "badge.svg"     // /dashboards/{id}/badge.svg
+	SVGSuffix           = ".svg"          // /dashboards/{id}.svg
+	TimezoneParam       = "tz"            // ?tz=UTC, zone of the timestamps in a response
+	TimeFormatParam     = "timeFormat"    // ?timeFormat=rfc3339, format of the timestamps in a response
+	FormatParam         = "format"        // ?format=csv, representation of a dashboard
+	ThemeParam          = "theme"         // ?theme=dark, theme of SVG cards and badges
+	MetricParam         = "metric"        // ?metric=temperature, metric shown on an SVG badge
+	FieldsParam         = "fields"        // ?fields=temperature,capital, features returned for a single request
+	CurrenciesParam     = "currencies"    // ?currencies=EUR,JPY, target currencies for a single request
+	RotateSecretSegment = "rotate-secret" // /notifications/{id}/rotate-secret
+	RedriveSegment      = "redrive"       // /deliveries/{id}/redrive
+	DeliveriesSegment   = "deliveries"    // /notifications/{id}/deliveries
+	TestSegment         = "test"          // /notifications/{id}/test
 
 	// webhook event constants
-	EventRegister = "REGISTER"
-	EventChange   = "CHANGE"
-	EventDelete   = "DELETE"
-	EventInvoke   = "INVOKE"
+	EventRegister     = "REGISTER"
+	EventChange       = "CHANGE"
+	EventDelete       = "DELETE"
+	EventInvoke       = "INVOKE"
+	EventWeatherAlert = "WEATHER_ALERT" // A subscription's weather condition started or stopped holding
+	EventRateChange   = "RATE_CHANGE"   // An exchange rate moved more than a subscription's threshold
+	EventWildcard     = "*"             // Subscribes a webhook to all registration events
+
+	// Weather alert conditions and states
+	ConditionBelow = "below"
+	ConditionAbove = "above"
+	AlertTriggered = "triggered"
+	AlertCleared   = "cleared"
 
 	// Firestore project and file config
 	FirebaseProjectID    = "demo-test-project"
@@ -24,6 +56,51 @@ const (
 	EnvFirestoreEmulator = "FIRESTORE_EMULATOR_HOST"
 	EnvGoEnv             = "GO_ENV"
 	EnvGoEnvTestValue    = "test"
+
+	// Country lookup config
+	EnvCountryAliasesFile    = "COUNTRY_ALIASES_FILE"
+	CountryCatalogueTTL      = 24 * time.Hour
+	MaxCountrySuggestions    = 3
+	MaxConcurrentMembers     = 8 // Member countries populated in parallel for region dashboards
+	CatalogueRefreshInterval = 6 * time.Hour
+	CatalogueRetryBackoff    = time.Minute // Wait after a failed catalogue refresh before fetching again
+	DefaultPageSize          = 50
+	MaxPageSize              = 250
+	DefaultSuggestions       = 10
+	CurrencyCatalogueBase    = "EUR" // Base currency used to list the codes the currency API supports
+
+	// Webhook signing config
+	WebhookSecretBytes       = 32
+	WebhookSecretPrefix      = "whsec_"
+	DefaultSecretGracePeriod = 24 * time.Hour // Old secrets keep signing deliveries this long after a rotation
+	MaxSecretGracePeriod     = 7 * 24 * time.Hour
+
+	// Webhook delivery queue config
+	WebhookTimeout            = 10 * time.Second
+	WebhookResponseSnippet    = 512                        // Bytes of a response body kept for troubleshooting
+	EnvWebhookAllowedNetworks = "WEBHOOK_ALLOWED_NETWORKS" // e.g. 10.0.0.0/8,127.0.0.1; private addresses webhooks may be delivered to
+	MaxDeliveryAttempts       = 8                          // Deliveries are dead-lettered after this many failed attempts
+	DeliveryBackoffBase       = 30 * time.Second           // Wait before the first retry, doubled for every further attempt
+	DeliveryBackoffMax        = time.Hour
+	DeliveryPollInterval      = 15 * time.Second
+	DeliveryLease             = time.Minute // A claimed delivery is not attempted again by others for this long
+
+	// Webhook delivery log config
+	EnvDeliveryLogRetention  = "DELIVERY_LOG_RETENTION" // e.g. 720h; how long delivery attempts are kept
+	DeliveryLogRetention     = 30 * 24 * time.Hour
+	DeliveryLogPruneInterval = time.Hour
+
+	// Weather alert config
+	WeatherAlertInterval = 15 * time.Minute
+
+	// Exchange-rate alert config
+	RateMonitorInterval = 5 * time.Minute
+	MaxRateWindow       = 7 * 24 * time.Hour // Longest window a rate change can be measured over
+
+	// Webhook delivery states
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
 )
 
 var (
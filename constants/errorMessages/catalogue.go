@@ -0,0 +1,123 @@
+package errorMessages
+
+import (
+	"sort"
+	"strings"
+)
+
+// Languages with a message catalogue. English is the language of the constants themselves.
+const (
+	LanguageEnglish   = "en"
+	LanguageNorwegian = "nb"
+)
+
+// languageAliases maps language tags to the catalogue they use.
+var languageAliases = map[string]string{
+	"en": LanguageEnglish,
+	"nb": LanguageNorwegian,
+	"no": LanguageNorwegian,
+	"nn": LanguageNorwegian,
+}
+
+// catalogue holds the translations of the API error messages, keyed by the English message.
+var catalogue = map[string]map[string]string{
+	LanguageNorwegian: {
+		MethodNotAllowed:        "metoden er ikke tillatt",
+		FirestoreError:          "kunne ikke lagre til Firestore: ",
+		InvalidJSON:             "ugyldige JSON-data",
+		InvalidRegistrationID:   "ugyldig registrerings-ID",
+		DeleteError:             "kunne ikke slette registreringen: ",
+		UpdateError:             "kunne ikke oppdatere registreringen: ",
+		NoIDProvided:            "ingen ID oppgitt i forespørselen",
+		RegisterNotFound:        "fant ingen registrering med oppgitt ID ",
+		NotificationNotFound:    "fant ikke varslingen",
+		DeserializationError:    "feil ved deserialisering: ",
+		ExtractionError:         "kunne ikke hente registreringsdata",
+		ReadingError:            "feil ved lesing av eksisterende registrering",
+		NoCountryProvided:       "intet land oppgitt i forespørselen",
+		NoCountryOrISOProvided:  "enten et landnavn eller en ISO-kode må oppgis",
+		ConflictingTargets:      "en registrering gjelder enten ett land, en liste med land eller en region",
+		StatusEncodeError:       "kunne ikke kode statussvaret",
+		CompareNeedsTwoIDs:      "minst to registrerings-ID-er trengs for en sammenligning",
+		InvalidPagination:       "page og limit må være positive heltall",
+		InvalidTimeSettings:     "ugyldig tidssone eller tidsformat",
+		NotAcceptable:           "formatet støttes ikke, bruk json, csv, xml, html eller text",
+		UnknownTheme:            "ukjent tema, bruk light eller dark",
+		UnknownMetric:           "ukjent merkeverdi",
+		UnknownField:            "ukjent dashbordfelt",
+		SecretGenerationError:   "kunne ikke lage webhook-hemmelighet: ",
+		InvalidGracePeriod:      "gracePeriod må være en varighet mellom 0s og 168h",
+		DeliveryNotFound:        "fant ikke webhook-leveransen",
+		DeliveryNotDead:         "bare døde leveranser kan sendes på nytt",
+		InvalidDeliveryStatus:   "status må være pending, delivered eller dead",
+		InvalidDeliveryOutcome:  "status må være success eller failed",
+		InvalidTimeRange:        "since og until må være RFC 3339-tidspunkter",
+		InvalidWebhookEvent:     "hendelser må være REGISTER, CHANGE, DELETE, INVOKE, WEATHER_ALERT, RATE_CHANGE eller *",
+		InvalidWebhookURL:       "url må være en absolutt http- eller https-URL",
+		InvalidWeatherCondition: "WEATHER_ALERT-abonnementer trenger ett land og en betingelse med metric temperature eller precipitation, operator below eller above, og en terskel",
+		InvalidRateCondition:    "RATE_CHANGE-abonnementer trenger en rateCondition med ulike base- og målvalutaer, en terskel i prosent over 0 og et vindu mellom 5m og 168h",
+		InvalidInvokeEvery:      "invokeEvery må være et positivt antall kall og krever et INVOKE-abonnement",
+		MissingQuery:            "mangler spørreparameteren q",
+		IsoCodeDoesNotMatch:     "ISO-koden samsvarer ikke med landnavnet i forespørselen",
+		IsoRequired:             "ISO-kode er påkrevd for denne forespørselen",
+		ISOCodeMismatch:         "ISO-koden samsvarer ikke med det oppgitte landet",
+		InvalidISOCodeFormat:    "ugyldig ISO-kodeformat i svaret fra API-et",
+		InvalidISOCode:          "ISO-koden må være en alpha-2-, alpha-3- eller numerisk kode",
+		APIFailed:               "kunne ikke validere landet mot det eksterne API-et",
+		APINotFound:             "det eksterne API-et svarte med 404, fant ikke landet",
+		APIUnexpectedStatus:     "det eksterne API-et svarte med uventet status",
+		NoDataFoundForCountry:   "fant ingen data for landet",
+		DidYouMean:              "mente du",
+		AliasRequired:           "alias og isoCode er påkrevd",
+		AliasNotFound:           "fant ikke aliaset",
+		RegionNotFound:          "fant ikke regionen, underregionen eller gruppen",
+		CurrencyNotFound:        "valutaen støttes ikke av valutakurskilden",
+		UnsupportedCurrencies:   "målvalutaer som ikke støttes",
+		DashboardConfigNotFound: "fant ikke dashbordkonfigurasjonen",
+		NotificationDeleted:     "varslingen ble slettet",
+	},
+}
+
+// MessageLanguage returns the first of the preferred languages with a message catalogue, or English.
+func MessageLanguage(preferred []string) string {
+	for _, lang := range preferred {
+		if supported, ok := languageAliases[strings.ToLower(lang)]; ok {
+			return supported
+		}
+	}
+	return LanguageEnglish
+}
+
+// catalogueKeys holds the English messages of each catalogue, longest first, so the longest matching message wins.
+var catalogueKeys = func() map[string][]string {
+	keys := make(map[string][]string, len(catalogue))
+	for language, translations := range catalogue {
+		for key := range translations {
+			keys[language] = append(keys[language], key)
+		}
+		sort.Slice(keys[language], func(i, j int) bool { return len(keys[language][i]) > len(keys[language][j]) })
+	}
+	return keys
+}()
+
+// Localize translates the catalogue message msg starts with to the given language.
+// Messages are often followed by details, e.g. "no data found for country: Norway", which may hold
+// upstream error text, so only the leading message is translated and the details are kept as they are.
+// The one exception is the "did you mean" of country suggestions.
+func Localize(msg string, language string) string {
+	translations, ok := catalogue[language]
+	if !ok {
+		return msg
+	}
+	for _, key := range catalogueKeys[language] {
+		if !strings.HasPrefix(msg, key) {
+			continue
+		}
+		details := msg[len(key):]
+		if translation, ok := translations[DidYouMean]; ok {
+			details = strings.Replace(details, "("+DidYouMean+": ", "("+translation+": ", 1)
+		}
+		return translations[key] + details
+	}
+	return msg
+}
@@ -2,20 +2,30 @@ package errorMessages
 
 // General error messages for the API
 const (
-	MethodNotAllowed      = "attempted method not allowed"
-	FirestoreError        = "could not store to Firestore: "
-	InvalidJSON           = "invalid JSON data"
-	InvalidRegistrationID = "invalid registration ID"
-	DeleteError           = "could not delete registration: "
-	UpdateError           = "could not update registration: "
-	NoIDProvided          = "no ID provided in the request"
-	RegisterNotFound      = "no register found with given ID "
-	NotificationNotFound  = "notification not found"
-	DeserializationError  = "error with deserialization: "
-	ExtractionError       = "failed to extract registration data"
-	ReadingError          = "error reading existing registration"
-	NoCountryProvided     = "no country provided in the request"
-	StatusEncodeError     = "failed to encode status response"
+	MethodNotAllowed       = "attempted method not allowed"
+	FirestoreError         = "could not store to Firestore: "
+	InvalidJSON            = "invalid JSON data"
+	InvalidRegistrationID  = "invalid registration ID"
+	DeleteError            = "could not delete registration: "
+	UpdateError            = "could not update registration: "
+	NoIDProvided           = "no ID provided in the request"
+	RegisterNotFound       = "no register found with given ID "
+	NotificationNotFound   = "notification not found"
+	DeserializationError   = "error with deserialization: "
+	ExtractionError        = "failed to extract registration data"
+	ReadingError           = "error reading existing registration"
+	NoCountryProvided      = "no country provided in the request"
+	NoCountryOrISOProvided = "either a country name or an ISO code must be provided"
+	ConflictingTargets     = "a registration targets either a country, a list of countries or a region"
+	StatusEncodeError      = "failed to encode status response"
+	CompareNeedsTwoIDs     = "at least two registration IDs are needed for a comparison"
+	InvalidPagination      = "page and limit must be positive integers"
+	MissingQuery           = "missing query parameter q"
+	InvalidTimeSettings    = "invalid timezone or time format"
+	NotAcceptable          = "requested format is not supported, use json, csv, xml, html or text"
+	UnknownTheme           = "unknown theme, use light or dark"
+	UnknownMetric          = "unknown badge metric"
+	UnknownField           = "unknown dashboard field"
 )
 
 // ISO Code validation errors
@@ -24,10 +34,12 @@ const (
 	IsoRequired           = "ISO code is required for this request"
 	ISOCodeMismatch       = "ISO code does not match the provided country"
 	InvalidISOCodeFormat  = "invalid ISO code format in API response"
+	InvalidISOCode        = "ISO code must be an alpha-2, alpha-3 or numeric code"
 	APIFailed             = "failed to validate country with external API"
 	APINotFound           = "external API returned a 404 status, country not found"
 	APIUnexpectedStatus   = "external API returned unexpected status"
 	NoDataFoundForCountry = "no data found for country"
+	DidYouMean            = "did you mean"
 )
 
 // Webhook errors
@@ -36,11 +48,31 @@ const (
 	WebhookPayloadMarshallingError = "error marshalling webhook payload: %v"
 	WebhookRequestCreationError    = "error creating webhook request: %v"
 	WebhookSendError               = "error sending webhook to %s: %v"
+	SecretGenerationError          = "could not generate webhook secret: "
+	InvalidGracePeriod             = "gracePeriod must be a duration between 0s and 168h"
+	DeliveryNotFound               = "webhook delivery not found"
+	DeliveryNotDead                = "only dead-lettered deliveries can be re-driven"
+	InvalidDeliveryStatus          = "status must be pending, delivered or dead"
+	InvalidDeliveryOutcome         = "status must be success or failed"
+	InvalidTimeRange               = "since and until must be RFC 3339 timestamps"
+	WebhookNotFound                = "webhook registration no longer exists"
+	WebhookRejected                = "receiver responded with status %d"
+	InvalidWebhookEvent            = "events must be REGISTER, CHANGE, DELETE, INVOKE, WEATHER_ALERT, RATE_CHANGE or *"
+	InvalidWebhookURL              = "url must be an absolute http or https URL"
+	InvalidRateCondition           = "RATE_CHANGE subscriptions need a rateCondition with different base and target currencies, a threshold in percent above 0, and a window between 5m and 168h"
+	InvalidInvokeEvery             = "invokeEvery must be a positive number of invocations and needs an INVOKE subscription"
+	InvalidWeatherCondition        = "WEATHER_ALERT subscriptions need a single country and a condition with metric temperature or precipitation, operator below or above, and a threshold"
+	BlockedWebhookAddress          = "webhook address is not allowed"
 )
 
 // Country-related errors
 const (
-	CountryNotRecognized = "country is not recognized: %v"
+	CountryNotRecognized  = "country is not recognized: %v"
+	AliasRequired         = "alias and isoCode are required"
+	AliasNotFound         = "alias not found"
+	RegionNotFound        = "region, subregion or group not found"
+	CurrencyNotFound      = "currency not supported by the exchange-rate source"
+	UnsupportedCurrencies = "unsupported target currencies"
 )
 
 // Firestore errors
@@ -0,0 +1,130 @@
+package firestore
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/models"
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+var (
+	ErrDeliveryNotFound = errors.New(errorMessages.DeliveryNotFound)
+	ErrDeliveryNotDue   = errors.New("delivery is not due")
+)
+
+// AddDelivery stores a new delivery in the queue and returns its ID.
+func AddDelivery(delivery *models.WebhookDelivery) (string, error) {
+	docRef, _, err := Client.Collection("deliveries").Add(context.Background(), delivery)
+	if err != nil {
+		return "", err
+	}
+	delivery.ID = docRef.ID
+	return docRef.ID, nil
+}
+
+// GetDelivery retrieves the delivery with the given ID.
+func GetDelivery(id string) (*models.WebhookDelivery, error) {
+	doc, err := Client.Collection("deliveries").Doc(id).Get(context.Background())
+	if err != nil {
+		return nil, ErrDeliveryNotFound
+	}
+
+	var delivery models.WebhookDelivery
+	if err := doc.DataTo(&delivery); err != nil {
+		return nil, err
+	}
+
+	delivery.ID = doc.Ref.ID
+	return &delivery, nil
+}
+
+// SaveDelivery overwrites the stored delivery with the given one.
+func SaveDelivery(delivery *models.WebhookDelivery) error {
+	delivery.UpdatedAt = time.Now()
+	_, err := Client.Collection("deliveries").Doc(delivery.ID).Set(context.Background(), delivery)
+	return err
+}
+
+/*
+ClaimDelivery takes a pending delivery that is due for an attempt.
+The next attempt is pushed forward by the lease in the same transaction,
+so a delivery is never attempted by two workers at once.
+Returns ErrDeliveryNotDue if the delivery is not pending or not due yet.
+*/
+func ClaimDelivery(id string, now time.Time, lease time.Duration) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	docRef := Client.Collection("deliveries").Doc(id)
+
+	err := Client.RunTransaction(context.Background(), func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(docRef)
+		if err != nil {
+			return ErrDeliveryNotFound
+		}
+		if err := doc.DataTo(&delivery); err != nil {
+			return err
+		}
+		if delivery.Status != constants.DeliveryPending || delivery.NextAttempt.After(now) {
+			return ErrDeliveryNotDue
+		}
+		delivery.NextAttempt = now.Add(lease)
+		return tx.Update(docRef, []firestore.Update{{Path: "next_attempt", Value: delivery.NextAttempt}})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.ID = id
+	return &delivery, nil
+}
+
+// DueDeliveries returns the IDs of all pending deliveries whose next attempt is due.
+func DueDeliveries(now time.Time) ([]string, error) {
+	// Filtering on the time in code avoids a composite index on status and next_attempt
+	deliveries, err := ListDeliveries(constants.DeliveryPending, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, delivery := range deliveries {
+		if !delivery.NextAttempt.After(now) {
+			ids = append(ids, delivery.ID)
+		}
+	}
+	return ids, nil
+}
+
+// ListDeliveries returns the deliveries with the given status and webhook, oldest first. Empty filters match everything.
+func ListDeliveries(status, webhookID string) ([]models.WebhookDelivery, error) {
+	query := Client.Collection("deliveries").Query
+	if status != "" {
+		query = query.Where("status", "==", status)
+	}
+	if webhookID != "" {
+		query = query.Where("webhook_id", "==", webhookID)
+	}
+
+	docs, err := query.Documents(context.Background()).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(docs))
+	for _, doc := range docs {
+		var delivery models.WebhookDelivery
+		if err := doc.DataTo(&delivery); err != nil {
+			continue
+		}
+		delivery.ID = doc.Ref.ID
+		deliveries = append(deliveries, delivery)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+	})
+	return deliveries, nil
+}
@@ -0,0 +1,57 @@
+package firestore
+
+import (
+	"Country-Dashboard-Service/internal/models"
+	"context"
+	"sort"
+	"time"
+)
+
+// AddDeliveryAttempt records an attempt in the delivery log.
+func AddDeliveryAttempt(attempt *models.DeliveryAttempt) error {
+	docRef, _, err := Client.Collection("delivery_log").Add(context.Background(), attempt)
+	if err != nil {
+		return err
+	}
+	attempt.ID = docRef.ID
+	return nil
+}
+
+// ListDeliveryAttempts returns the logged attempts for the given webhook, newest first.
+func ListDeliveryAttempts(webhookID string) ([]models.DeliveryAttempt, error) {
+	docs, err := Client.Collection("delivery_log").Where("webhook_id", "==", webhookID).Documents(context.Background()).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := make([]models.DeliveryAttempt, 0, len(docs))
+	for _, doc := range docs {
+		var attempt models.DeliveryAttempt
+		if err := doc.DataTo(&attempt); err != nil {
+			continue
+		}
+		attempt.ID = doc.Ref.ID
+		attempts = append(attempts, attempt)
+	}
+	sort.Slice(attempts, func(i, j int) bool {
+		return attempts[i].Time.After(attempts[j].Time)
+	})
+	return attempts, nil
+}
+
+// PruneDeliveryAttempts deletes all logged attempts made before the given time and returns how many were deleted.
+func PruneDeliveryAttempts(before time.Time) (int, error) {
+	docs, err := Client.Collection("delivery_log").Where("time", "<", before).Documents(context.Background()).GetAll()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, doc := range docs {
+		if _, err := doc.Ref.Delete(context.Background()); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
@@ -0,0 +1,36 @@
+package firestore
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+// IncrementInvocations counts an invocation of a dashboard of the country and returns the new count.
+// The counter is read and incremented in one transaction, so concurrent invocations each get their own count.
+func IncrementInvocations(country string) (int64, error) {
+	docRef := Client.Collection("invocations").Doc(strings.ToUpper(country))
+	var count int64
+	err := Client.RunTransaction(context.Background(), func(ctx context.Context, tx *firestore.Transaction) error {
+		// GetAll returns a snapshot without data instead of an error for the first invocation of a country
+		docs, err := tx.GetAll([]*firestore.DocumentRef{docRef})
+		if err != nil {
+			return err
+		}
+		count = 1
+		if docs[0].Exists() {
+			if previous, err := docs[0].DataAt("count"); err == nil {
+				if n, ok := previous.(int64); ok {
+					count = n + 1
+				}
+			}
+		}
+		return tx.Set(docRef, map[string]interface{}{
+			"count":      firestore.Increment(1),
+			"updated_at": time.Now(),
+		}, firestore.MergeAll)
+	})
+	return count, err
+}
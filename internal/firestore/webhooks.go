@@ -0,0 +1,95 @@
+package firestore
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/models"
+	"context"
+	"log"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+/*
+WebhooksForEvent returns the webhooks subscribed to the given event, by name or with the wildcard.
+Registrations holding a list of events are matched with array-contains-any,
+older registrations with a single event field by equality. A webhook matching both is returned once.
+*/
+func WebhooksForEvent(event string) ([]models.WebhookRegistration, error) {
+	webhooks := Client.Collection("notifications")
+	queries := []firestore.Query{
+		webhooks.Where("events", "array-contains-any", []string{event, constants.EventWildcard}),
+		webhooks.Where("event", "==", event),
+	}
+
+	var matches []models.WebhookRegistration
+	seen := make(map[string]bool)
+	for _, query := range queries {
+		docs, err := query.Documents(context.Background()).GetAll()
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range docs {
+			if seen[doc.Ref.ID] {
+				continue
+			}
+			seen[doc.Ref.ID] = true
+
+			var entry models.WebhookRegistration
+			if err := doc.DataTo(&entry); err != nil {
+				log.Printf(errorMessages.FailedWebhookDeserialization, err)
+				continue
+			}
+			entry.ID = doc.Ref.ID
+			matches = append(matches, entry)
+		}
+	}
+	return matches, nil
+}
+
+// SetWebhookAlertActive records whether a weather alert subscription's condition holds.
+// Returns false if it was already recorded, so each change of the condition is only acted on once.
+func SetWebhookAlertActive(id string, active bool) (bool, error) {
+	docRef := Client.Collection("notifications").Doc(id)
+	changed := false
+	err := Client.RunTransaction(context.Background(), func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(docRef)
+		if err != nil {
+			return err
+		}
+		var entry models.WebhookRegistration
+		if err := doc.DataTo(&entry); err != nil {
+			return err
+		}
+		changed = entry.AlertActive != active
+		if !changed {
+			return nil
+		}
+		return tx.Update(docRef, []firestore.Update{{Path: "alert_active", Value: active}})
+	})
+	return changed, err
+}
+
+// SetWebhookRateAlert records the time of the rate a RATE_CHANGE event was sent for.
+// Returns false if the stored time is no longer the previous one, meaning another instance already sent the event.
+func SetWebhookRateAlert(id string, previous, at time.Time) (bool, error) {
+	docRef := Client.Collection("notifications").Doc(id)
+	changed := false
+	err := Client.RunTransaction(context.Background(), func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(docRef)
+		if err != nil {
+			return err
+		}
+		var entry models.WebhookRegistration
+		if err := doc.DataTo(&entry); err != nil {
+			return err
+		}
+		changed = entry.LastRateAlert.Equal(previous)
+		if !changed {
+			return nil
+		}
+		return tx.Update(docRef, []firestore.Update{{Path: "last_rate_alert", Value: at}})
+	})
+	return changed, err
+}
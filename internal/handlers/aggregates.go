@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/utils"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memberResult holds a populated member country and the info it was built from.
+type memberResult struct {
+	info      *models.CountryInfo
+	dashboard *models.PopulatedDashboard
+}
+
+// populateAggregate builds the dashboard of a multi-country or region registration,
+// with a dashboard for every member country and aggregates over all of them.
+// Members that can't be populated are left out, but at least one has to succeed.
+func populateAggregate(config *models.Registration, lookups *dashboardLookups) (*models.PopulatedDashboard, error) {
+	results := make([]*memberResult, len(config.Members))
+
+	// Populate members concurrently, regions can hold dozens of countries
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, constants.MaxConcurrentMembers)
+	for i, code := range config.Members {
+		wg.Add(1)
+		go func(i int, code string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			info, err := lookups.countryByCode(code)
+			if err != nil {
+				log.Printf("Skipping member %s: %v", code, err)
+				return
+			}
+			member, err := buildDashboard(info, config.Features, lookups)
+			if err != nil {
+				log.Printf("Skipping member %s: %v", code, err)
+				return
+			}
+			if config.Neighbours {
+				member.Neighbours = populateNeighbours(info, config.Features, lookups)
+			}
+			results[i] = &memberResult{info: info, dashboard: member}
+		}(i, code)
+	}
+	wg.Wait()
+
+	members := make([]models.PopulatedDashboard, 0, len(results))
+	aggregates := &models.DashboardAggregates{}
+	var temperatures, precipitations []float64
+	rates := make(map[string][]float64)
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		aggregates.Countries++
+		aggregates.TotalPopulation += result.info.Population
+		aggregates.TotalArea += result.info.Area
+		if config.Features.Temperature {
+			temperatures = append(temperatures, result.dashboard.Features.Temperature)
+		}
+		if config.Features.Precipitation {
+			precipitations = append(precipitations, result.dashboard.Features.Precipitation)
+		}
+		for currency, rate := range result.dashboard.Features.TargetCurrencies {
+			rates[currency] = append(rates[currency], rate)
+		}
+		members = append(members, *result.dashboard)
+	}
+
+	if len(members) == 0 {
+		return nil, &dashboardError{http.StatusBadGateway, errorMessages.APIFailed, errors.New("no member country could be populated")}
+	}
+
+	if len(temperatures) > 0 {
+		meanTemperature := roundTo(mean(temperatures), 2)
+		aggregates.MeanTemperature = &meanTemperature
+	}
+	if len(precipitations) > 0 {
+		meanPrecipitation := roundTo(mean(precipitations), 2)
+		aggregates.MeanPrecipitation = &meanPrecipitation
+	}
+	if len(rates) > 0 {
+		aggregates.ExchangeRates = make(map[string]models.ExchangeRateSpread, len(rates))
+		for currency, values := range rates {
+			aggregates.ExchangeRates[currency] = rateSpread(values)
+		}
+	}
+
+	return &models.PopulatedDashboard{
+		Country:       aggregateName(config),
+		Region:        config.Region,
+		Members:       members,
+		Aggregates:    aggregates,
+		LastRetrieval: utils.CustomTime{Time: time.Now()},
+	}, nil
+}
+
+// aggregateName returns the display name of a multi-country or region registration.
+func aggregateName(config *models.Registration) string {
+	if config.Region != "" {
+		return config.Region
+	}
+	return strings.Join(config.Countries, ", ")
+}
+
+// dashboardCountries returns the ISO codes a populated dashboard is about,
+// one per member for multi-country and region registrations.
+func dashboardCountries(config *models.Registration, dashboard *models.PopulatedDashboard) []string {
+	if len(config.Members) > 0 {
+		return config.Members
+	}
+	return []string{dashboard.ISOCode}
+}
+
+// rateSpread returns the lowest, highest and mean rate and the spread between them.
+func rateSpread(values []float64) models.ExchangeRateSpread {
+	spread := models.ExchangeRateSpread{Min: values[0], Max: values[0]}
+	for _, v := range values {
+		spread.Min = min(spread.Min, v)
+		spread.Max = max(spread.Max, v)
+	}
+	spread.Mean = roundTo(mean(values), 6)
+	spread.Spread = roundTo(spread.Max-spread.Min, 6)
+	return spread
+}
+
+// mean returns the average of the values.
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
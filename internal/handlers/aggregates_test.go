@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/firestore"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Setup mocked REST Countries API with a catalogue of Nordic and Western African countries
+func startMockRegionAPI(t *testing.T) func() {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"name": {"common": "Norway"}, "cca2": "NO", "region": "Europe", "subregion": "Northern Europe"},
+			{"name": {"common": "Sweden"}, "cca2": "SE", "cca3": "SWE", "ccn3": "752", "region": "Europe", "subregion": "Northern Europe"},
+			{"name": {"common": "Denmark"}, "cca2": "DK", "region": "Europe", "subregion": "Northern Europe"},
+			{"name": {"common": "Ghana"}, "cca2": "GH", "region": "Africa", "subregion": "Western Africa"},
+			{"name": {"common": "Senegal"}, "cca2": "SN", "region": "Africa", "subregion": "Western Africa"}
+		]`))
+	}))
+	useMockAPI(t, &constants.RestCountriesAPI, mock.URL)
+	if _, err := services.RefreshCountryCatalogue(); err != nil {
+		t.Fatalf("Failed to load mock catalogue: %v", err)
+	}
+	return mock.Close
+}
+
+// postRegistration posts a registration and returns the stored document
+func postRegistration(t *testing.T, reg models.Registration) (int, models.Registration) {
+	t.Helper()
+
+	payload, _ := json.Marshal(reg)
+	req := httptest.NewRequest(http.MethodPost, constants.Registrations, bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+
+	RegistrationsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		return w.Code, models.Registration{}
+	}
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	doc, err := firestore.Client.Collection("registrations").Doc(resp["id"].(string)).Get(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to read stored registration: %v", err)
+	}
+	var stored models.Registration
+	if err := doc.DataTo(&stored); err != nil {
+		t.Fatalf("Failed to decode stored registration: %v", err)
+	}
+	stored.ID = doc.Ref.ID
+	return w.Code, stored
+}
+
+func TestPostRegistration_Region(t *testing.T) {
+	closeMock := startMockRegionAPI(t)
+	defer closeMock()
+
+	code, stored := postRegistration(t, models.Registration{Region: "Western Africa"})
+	if code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", code)
+	}
+	if len(stored.Members) != 2 {
+		t.Errorf("expected 2 members in Western Africa, got %v", stored.Members)
+	}
+
+	code, stored = postRegistration(t, models.Registration{Region: "Nordic"})
+	if code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", code)
+	}
+	if len(stored.Members) != 3 {
+		t.Errorf("expected the 3 Nordic countries in the catalogue, got %v", stored.Members)
+	}
+}
+
+func TestPostRegistration_UnknownRegion(t *testing.T) {
+	closeMock := startMockRegionAPI(t)
+	defer closeMock()
+
+	code, _ := postRegistration(t, models.Registration{Region: "Atlantis"})
+	if code != http.StatusBadRequest {
+		t.Errorf("expected 400 Bad Request, got %d", code)
+	}
+}
+
+func TestPostRegistration_ConflictingTargets(t *testing.T) {
+	code, _ := postRegistration(t, models.Registration{Country: "Norway", Region: "Nordic"})
+	if code != http.StatusBadRequest {
+		t.Errorf("expected 400 Bad Request, got %d", code)
+	}
+}
+
+func TestGetPopulatedDashboard_MultiCountry(t *testing.T) {
+	closeMock := startMockNordicCountriesAPI(t)
+	defer closeMock()
+
+	reg := models.Registration{
+		Countries: []string{"Norway", "Sweden"},
+		Members:   []string{"NO", "SE"},
+		Features:  models.Features{Capital: true, Population: true},
+	}
+	docRef, _, err := firestore.Client.Collection("registrations").Add(context.Background(), reg)
+	if err != nil {
+		t.Fatalf("Failed to insert test registration: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, constants.Dashboards+docRef.ID, nil)
+	rec := httptest.NewRecorder()
+
+	GetPopulatedDashboard(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", rec.Code)
+	}
+
+	var dashboard models.PopulatedDashboard
+	if err := json.NewDecoder(rec.Body).Decode(&dashboard); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(dashboard.Members) != 2 || dashboard.Aggregates == nil {
+		t.Fatalf("Expected 2 members with aggregates, got %+v", dashboard)
+	}
+	if dashboard.Aggregates.Countries != 2 || dashboard.Aggregates.TotalPopulation != 15000000 {
+		t.Errorf("Unexpected aggregates: %+v", dashboard.Aggregates)
+	}
+	if dashboard.Aggregates.MeanTemperature != nil {
+		t.Errorf("Expected no mean temperature when temperature is disabled")
+	}
+}
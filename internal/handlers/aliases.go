@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/services"
+	"Country-Dashboard-Service/internal/utils"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// countryAlias is the request body for adding a country alias.
+type countryAlias struct {
+	Alias   string `json:"alias"`
+	IsoCode string `json:"isoCode"`
+}
+
+/*
+AliasesHandler manages the country alias dictionary used when resolving registration input.
+GET lists all aliases, POST adds one and DELETE removes the alias given in the path.
+*/
+func AliasesHandler(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	switch r.Method {
+	case http.MethodGet:
+		utils.Encode(w, http.StatusOK, services.CountryAliases())
+	case http.MethodPost:
+		postAlias(w, r)
+	case http.MethodDelete:
+		if len(parts) < 5 || parts[4] == "" {
+			http.Error(w, errorMessages.NoIDProvided, http.StatusBadRequest)
+			return
+		}
+		deleteAlias(w, parts[4])
+	default:
+		http.Error(w, errorMessages.MethodNotAllowed, http.StatusMethodNotAllowed)
+	}
+}
+
+// postAlias adds an alias after checking that the ISO code belongs to a real country.
+func postAlias(w http.ResponseWriter, r *http.Request) {
+	var alias countryAlias
+	if err := json.NewDecoder(r.Body).Decode(&alias); err != nil {
+		http.Error(w, errorMessages.InvalidJSON, http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(alias.Alias) == "" || alias.IsoCode == "" {
+		http.Error(w, errorMessages.AliasRequired, http.StatusBadRequest)
+		return
+	}
+	if !services.IsCountryCode(alias.IsoCode) {
+		http.Error(w, errorMessages.InvalidISOCode, http.StatusBadRequest)
+		return
+	}
+
+	info, err := services.GetCountryInfoByCode(alias.IsoCode)
+	if err != nil {
+		http.Error(w, errorMessages.APINotFound+": "+alias.IsoCode, http.StatusBadRequest)
+		return
+	}
+
+	services.AddCountryAlias(alias.Alias, info.ISOCode)
+	utils.Encode(w, http.StatusCreated, countryAlias{Alias: alias.Alias, IsoCode: info.ISOCode})
+}
+
+// deleteAlias removes an alias from the dictionary.
+func deleteAlias(w http.ResponseWriter, alias string) {
+	if !services.RemoveCountryAlias(alias) {
+		http.Error(w, errorMessages.AliasNotFound, http.StatusNotFound)
+		return
+	}
+	utils.Encode(w, http.StatusOK, map[string]interface{}{"alias": alias})
+}
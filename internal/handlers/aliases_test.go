@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Setup mocked REST Countries API serving a small catalogue and a 404 for name lookups
+func startMockCatalogueAPI(t *testing.T) func() {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/all"):
+			w.Write([]byte(`[
+				{"name": {"common": "Netherlands"}, "cca2": "NL", "cca3": "NLD", "ccn3": "528", "altSpellings": ["NL", "Holland"]},
+				{"name": {"common": "United Kingdom"}, "cca2": "GB", "cca3": "GBR", "ccn3": "826", "altSpellings": ["GB", "UK"]}
+			]`))
+		case strings.HasPrefix(r.URL.Path, "/alpha/GB"):
+			w.Write([]byte(`[{"name": {"common": "United Kingdom"}, "cca2": "GB", "cca3": "GBR", "ccn3": "826"}]`))
+		case strings.HasPrefix(r.URL.Path, "/alpha/NL"):
+			w.Write([]byte(`[{"name": {"common": "Netherlands"}, "cca2": "NL", "cca3": "NLD", "ccn3": "528"}]`))
+		default:
+			http.Error(w, "Not Found", http.StatusNotFound)
+		}
+	}))
+	useMockAPI(t, &constants.RestCountriesAPI, mock.URL)
+	if _, err := services.RefreshCountryCatalogue(); err != nil {
+		t.Fatalf("Failed to load mock catalogue: %v", err)
+	}
+	return mock.Close
+}
+
+func TestPostRegistration_Alias(t *testing.T) {
+	closeMock := startMockCatalogueAPI(t)
+	defer closeMock()
+
+	for _, country := range []string{"UK", "Holland"} {
+		payload, _ := json.Marshal(models.Registration{Country: country})
+
+		req := httptest.NewRequest(http.MethodPost, constants.Registrations, bytes.NewReader(payload))
+		w := httptest.NewRecorder()
+
+		RegistrationsHandler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected 200 OK for %s, got %d: %s", country, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestPostRegistration_DidYouMean(t *testing.T) {
+	closeMock := startMockCatalogueAPI(t)
+	defer closeMock()
+
+	payload, _ := json.Marshal(models.Registration{Country: "Netherlnds"})
+
+	req := httptest.NewRequest(http.MethodPost, constants.Registrations, bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+
+	RegistrationsHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "did you mean") || !strings.Contains(w.Body.String(), "Netherlands") {
+		t.Errorf("expected a suggestion for Netherlands, got: %s", w.Body.String())
+	}
+}
+
+func TestAliases_AddAndDelete(t *testing.T) {
+	closeMock := startMockCatalogueAPI(t)
+	defer closeMock()
+
+	payload, _ := json.Marshal(countryAlias{Alias: "Blighty", IsoCode: "GB"})
+	req := httptest.NewRequest(http.MethodPost, constants.Aliases, bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+
+	AliasesHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 Created, got %d", w.Code)
+	}
+	if code, ok := services.LookupCountryAlias("blighty"); !ok || code != "GB" {
+		t.Errorf("expected alias blighty -> GB, got %s (%v)", code, ok)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, constants.Aliases+"blighty", nil)
+	w = httptest.NewRecorder()
+
+	AliasesHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200 OK, got %d", w.Code)
+	}
+	if _, ok := services.LookupCountryAlias("blighty"); ok {
+		t.Errorf("expected alias to be removed")
+	}
+}
+
+func TestAliases_InvalidCode(t *testing.T) {
+	payload, _ := json.Marshal(countryAlias{Alias: "Somewhere", IsoCode: "not-a-code"})
+	req := httptest.NewRequest(http.MethodPost, constants.Aliases, bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+
+	AliasesHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 Bad Request, got %d", w.Code)
+	}
+}
+
+func TestSuggestCountries_AliasKeepsLimit(t *testing.T) {
+	closeMock := startMockCatalogueAPI(t)
+	defer closeMock()
+	services.AddCountryAlias("Nether", "GB")
+	defer services.RemoveCountryAlias("Nether")
+
+	req := httptest.NewRequest(http.MethodGet, constants.Countries+"suggest?q=nether&limit=1", nil)
+	w := httptest.NewRecorder()
+
+	CountriesHandler(w, req)
+
+	var suggestions []models.CountrySummary
+	if err := json.NewDecoder(w.Body).Decode(&suggestions); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(suggestions) != 1 || suggestions[0].ISOCode != "GB" {
+		t.Errorf("expected only the alias target within the limit, got %+v", suggestions)
+	}
+}
+
+func TestLoadCountryAliases_SkipsInvalidCodes(t *testing.T) {
+	closeMock := startMockCatalogueAPI(t)
+	defer closeMock()
+
+	path := filepath.Join(t.TempDir(), "aliases.json")
+	data := `{"Oranje": "nld", "Nowhere": "ZZ", "Broken": "not a code"}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("Failed to write alias file: %v", err)
+	}
+	if err := services.LoadCountryAliases(path); err != nil {
+		t.Fatalf("Failed to load alias file: %v", err)
+	}
+	defer services.RemoveCountryAlias("Oranje")
+
+	if code, ok := services.LookupCountryAlias("Oranje"); !ok || code != "NL" {
+		t.Errorf("Expected Oranje to resolve to NL, got %q", code)
+	}
+	for _, alias := range []string{"Nowhere", "Broken"} {
+		if code, ok := services.LookupCountryAlias(alias); ok {
+			t.Errorf("Expected %s to be skipped, got %q", alias, code)
+		}
+	}
+}
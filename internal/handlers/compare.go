@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/firestore"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/utils"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// namedRanking describes a named ranking on a numeric feature, e.g. "warmest" is the highest temperature.
+type namedRanking struct {
+	name    string
+	feature string
+	highest bool
+}
+
+// namedRankings lists the rankings shown in a comparison, when the feature is present.
+var namedRankings = []namedRanking{
+	{"warmest", "temperature", true},
+	{"coldest", "temperature", false},
+	{"wettest", "precipitation", true},
+	{"driest", "precipitation", false},
+	{"largest", "area", true},
+	{"smallest", "area", false},
+	{"mostPopulous", "population", true},
+	{"leastPopulous", "population", false},
+	{"densest", "populationDensity", true},
+}
+
+// compareDashboards handles GET /dashboards/compare?ids=a,b,c.
+// Each registration is populated like a normal dashboard and the results are compared feature by feature.
+func compareDashboards(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, errorMessages.MethodNotAllowed, http.StatusMethodNotAllowed)
+		return
+	}
+
+	ids := splitIDs(r.URL.Query().Get("ids"))
+	if len(ids) == 0 {
+		http.Error(w, errorMessages.NoIDProvided, http.StatusBadRequest)
+		return
+	}
+	if len(ids) < 2 {
+		http.Error(w, errorMessages.CompareNeedsTwoIDs, http.StatusBadRequest)
+		return
+	}
+
+	settings, err := requestTimeSettings(r, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Load and populate every registration, sharing lookups between them
+	lookups := newDashboardLookups()
+	configs := make(map[string]*models.Registration, len(ids))
+	dashboards := make(map[string]models.PopulatedDashboard, len(ids))
+	for _, id := range ids {
+		config, err := firestore.GetDashboardConfigByID(id)
+		if err != nil {
+			http.Error(w, errorMessages.RegisterNotFound+id, http.StatusNotFound)
+			return
+		}
+		if err := applyFeatureOverrides(r, config); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		dashboard, err := populateDashboard(config, lookups)
+		if err != nil {
+			writeDashboardError(w, err)
+			return
+		}
+		configs[id] = config
+		dashboards[id] = *dashboard
+	}
+
+	languages := utils.PreferredLanguages(r)
+	for id, dashboard := range dashboards {
+		localizeDashboard(&dashboard, languages)
+		applyTimeSettings(&dashboard, settings)
+		dashboards[id] = dashboard
+	}
+
+	comparison := buildComparison(ids, configs, dashboards)
+	comparison.LastRetrieval = comparison.LastRetrieval.WithSettings(settings)
+	utils.Encode(w, http.StatusOK, comparison)
+}
+
+// buildComparison builds the feature table, deltas and rankings for the populated dashboards.
+func buildComparison(ids []string, configs map[string]*models.Registration, dashboards map[string]models.PopulatedDashboard) models.DashboardComparison {
+	table := make(map[string]models.FeatureComparison)
+	numeric := make(map[string]map[string]float64)
+
+	for _, id := range ids {
+		values, numbers := featureValues(configs[id].Features, dashboards[id].Features)
+		for feature, value := range values {
+			entry, ok := table[feature]
+			if !ok {
+				entry = models.FeatureComparison{Values: make(map[string]interface{})}
+			}
+			entry.Values[id] = value
+			table[feature] = entry
+		}
+		for feature, value := range numbers {
+			if numeric[feature] == nil {
+				numeric[feature] = make(map[string]float64)
+			}
+			numeric[feature][id] = value
+		}
+	}
+
+	// Deltas and rankings for numeric features
+	for feature, values := range numeric {
+		ranking := rankByValue(values)
+		entry := table[feature]
+		entry.Ranking = ranking
+		entry.Delta = roundTo(values[ranking[0]]-values[ranking[len(ranking)-1]], 4)
+		table[feature] = entry
+	}
+
+	rankings := make(map[string]models.RankedDashboard)
+	for _, named := range namedRankings {
+		values, ok := numeric[named.feature]
+		if !ok || len(values) < 2 {
+			continue
+		}
+		ranking := rankByValue(values)
+		top := ranking[0]
+		if !named.highest {
+			top = ranking[len(ranking)-1]
+		}
+		rankings[named.name] = models.RankedDashboard{
+			ID:      top,
+			Country: dashboards[top].Country,
+			Feature: named.feature,
+			Value:   values[top],
+		}
+	}
+
+	return models.DashboardComparison{
+		Dashboards:    dashboards,
+		Features:      table,
+		Rankings:      rankings,
+		LastRetrieval: utils.CustomTime{Time: time.Now()},
+	}
+}
+
+// featureValues flattens the enabled features of a dashboard into a table row.
+// Numeric features are also returned separately so they can be ranked.
+func featureValues(enabled models.Features, f models.DashboardFeatures) (map[string]interface{}, map[string]float64) {
+	values := make(map[string]interface{})
+	numbers := make(map[string]float64)
+
+	setNumber := func(feature string, value float64) {
+		values[feature] = value
+		numbers[feature] = value
+	}
+
+	if enabled.Temperature {
+		setNumber("temperature", f.Temperature)
+	}
+	if enabled.Precipitation {
+		setNumber("precipitation", f.Precipitation)
+	}
+	if enabled.Population {
+		setNumber("population", float64(f.Population))
+	}
+	if enabled.Area {
+		setNumber("area", f.Area)
+	}
+	if enabled.PopulationDensity {
+		setNumber("populationDensity", f.PopulationDensity)
+	}
+	for currency, rate := range f.TargetCurrencies {
+		setNumber("targetCurrencies."+currency, rate)
+	}
+
+	if enabled.Capital {
+		values["capital"] = f.Capital
+	}
+	if enabled.Coordinates {
+		values["coordinates"] = f.Coordinates
+	}
+	if enabled.Languages {
+		values["languages"] = f.Languages
+	}
+	if enabled.Borders {
+		values["borders"] = f.Borders
+	}
+	if enabled.Timezones {
+		values["timezones"] = f.Timezones
+	}
+	if enabled.Region {
+		values["region"] = f.Region
+		values["subregion"] = f.Subregion
+	}
+	if enabled.Flag {
+		values["flag"] = f.Flag
+	}
+	if enabled.CallingCode {
+		values["callingCodes"] = f.CallingCodes
+	}
+	if enabled.DrivingSide {
+		values["drivingSide"] = f.DrivingSide
+	}
+	if enabled.TopLevelDomain {
+		values["topLevelDomains"] = f.TopLevelDomains
+	}
+	if enabled.LocalTime && f.LocalTime != nil {
+		values["localTime"] = f.LocalTime
+	}
+	if enabled.Daylight && f.Daylight != nil {
+		values["daylight"] = f.Daylight
+	}
+	return values, numbers
+}
+
+// rankByValue returns the IDs ordered from the highest to the lowest value.
+func rankByValue(values map[string]float64) []string {
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if values[ids[i]] == values[ids[j]] {
+			return ids[i] < ids[j]
+		}
+		return values[ids[i]] > values[ids[j]]
+	})
+	return ids
+}
+
+// splitIDs splits a comma separated list of IDs, dropping empty entries and duplicates.
+func splitIDs(raw string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range strings.Split(raw, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// roundTo rounds a value to the given number of decimals.
+func roundTo(value float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/firestore"
+	"Country-Dashboard-Service/internal/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Utility function to insert a registration for the given country into Firestore
+func insertCountryRegistration(t *testing.T, country string, features models.Features) string {
+	t.Helper()
+
+	reg := models.Registration{Country: country, Features: features}
+	docRef, _, err := firestore.Client.Collection("registrations").Add(context.Background(), reg)
+	if err != nil {
+		t.Fatalf("Failed to insert test registration: %v", err)
+	}
+	return docRef.ID
+}
+
+// Setup mocked REST Countries API answering for Norway and Sweden
+func startMockNordicCountriesAPI(t *testing.T) func() {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "Sweden") || strings.HasSuffix(r.URL.Path, "/SE"):
+			w.Write([]byte(`[{"name": {"common": "Sweden"}, "cca2": "SE", "cca3": "SWE", "capital": ["Stockholm"],
+				"latlng": [62.0, 15.0], "population": 10000000, "area": 450295, "borders": ["NOR", "FIN"]}]`))
+		default:
+			w.Write([]byte(`[{"name": {"common": "Norway"}, "cca2": "NO", "cca3": "NOR", "capital": ["Oslo"],
+				"latlng": [62.0, 10.0], "population": 5000000, "area": 323802, "borders": ["SWE", "FIN", "RUS"]}]`))
+		}
+	}))
+	useMockAPI(t, &constants.RestCountriesAPI, mock.URL)
+	return mock.Close
+}
+
+func TestCompareDashboards(t *testing.T) {
+	closeMock := startMockNordicCountriesAPI(t)
+	defer closeMock()
+
+	features := models.Features{Capital: true, Population: true, Area: true}
+	norway := insertCountryRegistration(t, "Norway", features)
+	sweden := insertCountryRegistration(t, "Sweden", features)
+
+	req := httptest.NewRequest(http.MethodGet, constants.Dashboards+"compare?ids="+norway+","+sweden, nil)
+	rec := httptest.NewRecorder()
+
+	GetPopulatedDashboard(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var comparison models.DashboardComparison
+	if err := json.NewDecoder(rec.Body).Decode(&comparison); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(comparison.Dashboards) != 2 {
+		t.Errorf("Expected 2 dashboards, got %d", len(comparison.Dashboards))
+	}
+	population := comparison.Features["population"]
+	if population.Delta != 5000000 || len(population.Ranking) != 2 || population.Ranking[0] != sweden {
+		t.Errorf("Unexpected population comparison: %+v", population)
+	}
+	if comparison.Rankings["largest"].Country != "Sweden" || comparison.Rankings["smallest"].ID != norway {
+		t.Errorf("Unexpected rankings: %+v", comparison.Rankings)
+	}
+	if comparison.Features["capital"].Values[norway] != "Oslo" {
+		t.Errorf("Expected capital Oslo for Norway, got %v", comparison.Features["capital"].Values[norway])
+	}
+}
+
+func TestCompareDashboards_NeedsTwoIDs(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, constants.Dashboards+"compare?ids=only-one", nil)
+	rec := httptest.NewRecorder()
+
+	GetPopulatedDashboard(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 Bad Request, got %d", rec.Code)
+	}
+}
+
+func TestCompareDashboards_UnknownID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, constants.Dashboards+"compare?ids=missing-a,missing-b", nil)
+	rec := httptest.NewRecorder()
+
+	GetPopulatedDashboard(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 Not Found, got %d", rec.Code)
+	}
+}
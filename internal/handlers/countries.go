@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"Country-Dashboard-Service/internal/utils"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+/*
+CountriesHandler serves the cached country catalogue.
+GET /countries/ lists countries with optional region and subregion filters and pagination,
+and GET /countries/suggest?q=nor returns autocomplete suggestions.
+*/
+func CountriesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, errorMessages.MethodNotAllowed, http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) > 4 && parts[4] == constants.SuggestSegment {
+		suggestCountries(w, r)
+		return
+	}
+	listCountries(w, r)
+}
+
+// listCountries returns a page of the catalogue, filtered by region and subregion.
+func listCountries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page, errPage := positiveIntParam(query.Get("page"), 1)
+	limit, errLimit := positiveIntParam(query.Get("limit"), constants.DefaultPageSize)
+	if errPage != nil || errLimit != nil {
+		http.Error(w, errorMessages.InvalidPagination, http.StatusBadRequest)
+		return
+	}
+	limit = min(limit, constants.MaxPageSize)
+
+	catalogue, err := services.GetCountryCatalogue()
+	if err != nil {
+		http.Error(w, errorMessages.APIFailed, http.StatusBadGateway)
+		return
+	}
+
+	region := query.Get("region")
+	subregion := query.Get("subregion")
+	filtered := make([]models.CountrySummary, 0, len(catalogue))
+	for _, c := range catalogue {
+		if region != "" && !strings.EqualFold(c.Region, region) {
+			continue
+		}
+		if subregion != "" && !strings.EqualFold(c.Subregion, subregion) {
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+
+	start, end := pageBounds(len(filtered), page, limit)
+	utils.Encode(w, http.StatusOK, models.CountryPage{
+		Total:     len(filtered),
+		Page:      page,
+		Limit:     limit,
+		Countries: filtered[start:end],
+	})
+}
+
+// suggestCountries returns countries matching the start of, or closely resembling, the query.
+func suggestCountries(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		http.Error(w, errorMessages.MissingQuery, http.StatusBadRequest)
+		return
+	}
+	limit, err := positiveIntParam(r.URL.Query().Get("limit"), constants.DefaultSuggestions)
+	if err != nil {
+		http.Error(w, errorMessages.InvalidPagination, http.StatusBadRequest)
+		return
+	}
+
+	limit = min(limit, constants.MaxPageSize)
+	suggestions := services.SuggestCountries(q, limit)
+
+	// An alias like "UK" is the best match there is, so it goes first, pushing the last suggestion out if needed
+	if code, ok := services.LookupCountryAlias(q); ok {
+		suggestions = prependCountry(suggestions, code)
+		suggestions = suggestions[:min(len(suggestions), limit)]
+	}
+	utils.Encode(w, http.StatusOK, suggestions)
+}
+
+// prependCountry moves the catalogue entry with the given ISO code to the front of the list.
+func prependCountry(list []models.CountrySummary, isoCode string) []models.CountrySummary {
+	catalogue, err := services.GetCountryCatalogue()
+	if err != nil {
+		return list
+	}
+	for _, c := range catalogue {
+		if c.ISOCode != isoCode {
+			continue
+		}
+		result := []models.CountrySummary{c}
+		for _, other := range list {
+			if other.ISOCode != isoCode {
+				result = append(result, other)
+			}
+		}
+		return result
+	}
+	return list
+}
+
+// positiveIntParam parses a positive integer query parameter, returning the fallback if it is empty.
+func positiveIntParam(raw string, fallback int) (int, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 1 {
+		return 0, strconv.ErrSyntax
+	}
+	return value, nil
+}
+
+// pageBounds returns the slice bounds of a page of total items, or an empty range past the last page.
+// The page is checked against the number of pages before multiplying, so large page numbers can't overflow.
+func pageBounds(total, page, limit int) (start, end int) {
+	pages := (total + limit - 1) / limit
+	if page-1 >= pages {
+		return total, total
+	}
+	start = (page - 1) * limit
+	return start, min(start+limit, total)
+}
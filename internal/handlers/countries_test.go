@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListCountries_RegionFilterAndPagination(t *testing.T) {
+	closeMock := startMockRegionAPI(t)
+	defer closeMock()
+
+	req := httptest.NewRequest(http.MethodGet, constants.Countries+"?region=europe&limit=2&page=2", nil)
+	w := httptest.NewRecorder()
+
+	CountriesHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", w.Code)
+	}
+
+	var page models.CountryPage
+	if err := json.NewDecoder(w.Body).Decode(&page); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if page.Total != 3 || page.Page != 2 || len(page.Countries) != 1 {
+		t.Errorf("expected page 2 with 1 of 3 European countries, got %+v", page)
+	}
+}
+
+func TestListCountries_InvalidPage(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, constants.Countries+"?page=0", nil)
+	w := httptest.NewRecorder()
+
+	CountriesHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 Bad Request, got %d", w.Code)
+	}
+}
+
+func TestListCountries_PageBeyondLast(t *testing.T) {
+	closeMock := startMockRegionAPI(t)
+	defer closeMock()
+
+	req := httptest.NewRequest(http.MethodGet, constants.Countries+"?page=184467440737095517&limit=100", nil)
+	w := httptest.NewRecorder()
+
+	CountriesHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", w.Code)
+	}
+
+	var page models.CountryPage
+	if err := json.NewDecoder(w.Body).Decode(&page); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if page.Total != 5 || len(page.Countries) != 0 {
+		t.Errorf("expected an empty page of 5 countries, got %+v", page)
+	}
+}
+
+func TestSuggestCountries(t *testing.T) {
+	closeMock := startMockRegionAPI(t)
+	defer closeMock()
+
+	req := httptest.NewRequest(http.MethodGet, constants.Countries+"suggest?q=nor", nil)
+	w := httptest.NewRecorder()
+
+	CountriesHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", w.Code)
+	}
+
+	var suggestions []models.CountrySummary
+	if err := json.NewDecoder(w.Body).Decode(&suggestions); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(suggestions) == 0 || suggestions[0].ISOCode != "NO" {
+		t.Errorf("expected Norway as the first suggestion, got %+v", suggestions)
+	}
+}
+
+func TestSuggestCountries_MissingQuery(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, constants.Countries+"suggest", nil)
+	w := httptest.NewRecorder()
+
+	CountriesHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 Bad Request, got %d", w.Code)
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"Country-Dashboard-Service/internal/utils"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+/*
+CurrenciesHandler lists the currencies that can be used as target currencies.
+GET /currencies/ lists all supported currencies, optionally only those used by ?country=NO,
+and GET /currencies/{code} returns a single currency.
+*/
+func CurrenciesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, errorMessages.MethodNotAllowed, http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) > 4 && parts[4] != "" {
+		getCurrency(w, parts[4])
+		return
+	}
+	listCurrencies(w, r)
+}
+
+// listCurrencies returns all supported currencies, filtered by the country using them.
+func listCurrencies(w http.ResponseWriter, r *http.Request) {
+	currencies, err := services.GetCurrencyCatalogue()
+	if err != nil {
+		http.Error(w, errorMessages.APIFailed, http.StatusBadGateway)
+		return
+	}
+
+	country := strings.ToUpper(r.URL.Query().Get("country"))
+	if country == "" {
+		utils.Encode(w, http.StatusOK, currencies)
+		return
+	}
+	filtered := make([]models.CurrencyInfo, 0)
+	for _, c := range currencies {
+		if slices.Contains(c.Countries, country) {
+			filtered = append(filtered, c)
+		}
+	}
+	utils.Encode(w, http.StatusOK, filtered)
+}
+
+// getCurrency returns a single supported currency by code.
+func getCurrency(w http.ResponseWriter, code string) {
+	currency, ok, err := services.GetCurrency(code)
+	if err != nil {
+		http.Error(w, errorMessages.APIFailed, http.StatusBadGateway)
+		return
+	}
+	if !ok {
+		http.Error(w, errorMessages.CurrencyNotFound, http.StatusNotFound)
+		return
+	}
+	utils.Encode(w, http.StatusOK, currency)
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Setup mocked currency and REST Countries APIs and load the currency catalogue from them
+func startMockCurrencyCatalogueAPI(t *testing.T) func() {
+	mockCurrency := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"base": "EUR", "rates": {"NOK": 11.5, "SEK": 11.2, "USD": 1.08}}`))
+	}))
+	mockCountries := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"cca2": "NO", "currencies": {"NOK": {"name": "Norwegian krone", "symbol": "kr"}}},
+			{"cca2": "SJ", "currencies": {"NOK": {"name": "krone", "symbol": "kr"}}},
+			{"cca2": "DE", "currencies": {"EUR": {"name": "Euro", "symbol": "€"}}},
+			{"cca2": "ZW", "currencies": {"ZWL": {"name": "Zimbabwean dollar", "symbol": "$"}}}
+		]`))
+	}))
+	useMockAPI(t, &constants.CurrencyAPI, mockCurrency.URL+"/")
+	useMockAPI(t, &constants.RestCountriesAPI, mockCountries.URL)
+	if _, err := services.RefreshCurrencyCatalogue(); err != nil {
+		t.Fatalf("Failed to load mock currency catalogue: %v", err)
+	}
+	return func() {
+		mockCurrency.Close()
+		mockCountries.Close()
+	}
+}
+
+func TestListCurrencies(t *testing.T) {
+	closeMock := startMockCurrencyCatalogueAPI(t)
+	defer closeMock()
+
+	req := httptest.NewRequest(http.MethodGet, constants.Currencies, nil)
+	w := httptest.NewRecorder()
+
+	CurrenciesHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", w.Code)
+	}
+
+	var currencies []models.CurrencyInfo
+	if err := json.NewDecoder(w.Body).Decode(&currencies); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	// EUR is the base currency, and ZWL is not supported by the currency API
+	if len(currencies) != 4 {
+		t.Fatalf("expected EUR, NOK, SEK and USD, got %+v", currencies)
+	}
+	nok := currencies[1]
+	if nok.Code != "NOK" || nok.Name != "Norwegian krone" || nok.Symbol != "kr" || len(nok.Countries) != 2 {
+		t.Errorf("unexpected NOK metadata: %+v", nok)
+	}
+}
+
+func TestListCurrencies_CountryFilter(t *testing.T) {
+	closeMock := startMockCurrencyCatalogueAPI(t)
+	defer closeMock()
+
+	req := httptest.NewRequest(http.MethodGet, constants.Currencies+"?country=de", nil)
+	w := httptest.NewRecorder()
+
+	CurrenciesHandler(w, req)
+
+	var currencies []models.CurrencyInfo
+	if err := json.NewDecoder(w.Body).Decode(&currencies); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(currencies) != 1 || currencies[0].Code != "EUR" {
+		t.Errorf("expected only EUR for Germany, got %+v", currencies)
+	}
+}
+
+func TestGetCurrency_NotSupported(t *testing.T) {
+	closeMock := startMockCurrencyCatalogueAPI(t)
+	defer closeMock()
+
+	req := httptest.NewRequest(http.MethodGet, constants.Currencies+"ZWL", nil)
+	w := httptest.NewRecorder()
+
+	CurrenciesHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 Not Found, got %d", w.Code)
+	}
+}
+
+func TestPostRegistration_UnsupportedCurrency(t *testing.T) {
+	closeMock := startMockCurrencyCatalogueAPI(t)
+	defer closeMock()
+
+	code, _ := postRegistration(t, models.Registration{
+		Country:  "Norway",
+		Features: models.Features{TargetCurrencies: []string{"usd", "XYZ"}},
+	})
+	if code != http.StatusBadRequest {
+		t.Errorf("expected 400 Bad Request, got %d", code)
+	}
+}
+
+func TestCurrenciesMethodNotAllowed(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, constants.Currencies, strings.NewReader("{}"))
+	w := httptest.NewRecorder()
+
+	CurrenciesHandler(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 Method Not Allowed, got %d", w.Code)
+	}
+}
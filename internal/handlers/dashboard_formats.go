@@ -0,0 +1,353 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Representations a dashboard can be returned in
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+	formatXML  = "xml"
+	formatHTML = "html"
+	formatText = "text"
+)
+
+// dashboardFormats maps ?format= values and media types to representations.
+var dashboardFormats = map[string]string{
+	formatJSON:         formatJSON,
+	formatCSV:          formatCSV,
+	formatXML:          formatXML,
+	formatHTML:         formatHTML,
+	formatText:         formatText,
+	"txt":              formatText,
+	"application/json": formatJSON,
+	"text/csv":         formatCSV,
+	"application/xml":  formatXML,
+	"text/xml":         formatXML,
+	"text/html":        formatHTML,
+	"text/plain":       formatText,
+	"*/*":              formatJSON,
+	"application/*":    formatJSON,
+	"text/*":           formatText,
+}
+
+// negotiateDashboardFormat picks the representation from ?format=, or else from the Accept header.
+// It returns false if none of the requested representations are supported.
+func negotiateDashboardFormat(r *http.Request) (string, bool) {
+	if requested := r.URL.Query().Get(constants.FormatParam); requested != "" {
+		format, ok := dashboardFormats[strings.ToLower(requested)]
+		return format, ok
+	}
+
+	accept := strings.TrimSpace(r.Header.Get("Accept"))
+	if accept == "" {
+		return formatJSON, true
+	}
+
+	type weighted struct {
+		mediaType string
+		quality   float64
+	}
+	var accepted []weighted
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if q, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if quality > 0 {
+			accepted = append(accepted, weighted{strings.ToLower(strings.TrimSpace(mediaType)), quality})
+		}
+	}
+	sort.SliceStable(accepted, func(i, j int) bool { return accepted[i].quality > accepted[j].quality })
+
+	for _, a := range accepted {
+		if format, ok := dashboardFormats[a.mediaType]; ok && strings.Contains(a.mediaType, "/") {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// writeDashboard writes the dashboard in the negotiated representation.
+func writeDashboard(w http.ResponseWriter, format string, dashboard *models.PopulatedDashboard) error {
+	w.Header().Set("Vary", "Accept")
+	if format == formatJSON {
+		w.Header().Set("Content-Type", "application/json")
+		return json.NewEncoder(w).Encode(dashboard)
+	}
+
+	data, err := json.Marshal(dashboard)
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	switch format {
+	case formatXML:
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		err = dashboardXML(&body, data)
+	case formatCSV:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		err = dashboardCSV(&body, dashboardSections(dashboard))
+	case formatHTML:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		err = dashboardPage.Execute(&body, dashboardSections(dashboard))
+	default:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		err = dashboardText(&body, dashboardSections(dashboard))
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body.Bytes())
+	return err
+}
+
+// dashboardSection holds the flattened features of one country of a dashboard.
+type dashboardSection struct {
+	Country string
+	ISOCode string
+	Rows    []dashboardRow
+}
+
+// dashboardRow is a single flattened feature, e.g. "coordinates.latitude" or the currency row "targetCurrencies.EUR".
+type dashboardRow struct {
+	Feature string
+	Value   string
+}
+
+// dashboardSections flattens a dashboard into one section for the dashboard itself,
+// followed by one for each member and neighbour.
+func dashboardSections(dashboard *models.PopulatedDashboard) []dashboardSection {
+	main := dashboardSection{Country: dashboard.Country, ISOCode: dashboard.ISOCode}
+	main.Rows = flattenFeatures(dashboard)
+	if dashboard.Region != "" {
+		main.Rows = append(main.Rows, dashboardRow{"region", dashboard.Region})
+	}
+	if dashboard.Aggregates != nil {
+		data, _ := json.Marshal(dashboard.Aggregates)
+		flattenJSON(data, "aggregates", func(feature, value string) {
+			main.Rows = append(main.Rows, dashboardRow{feature, value})
+		})
+	}
+	retrieval, _ := dashboard.LastRetrieval.MarshalJSON()
+	main.Rows = append(main.Rows, dashboardRow{"lastRetrieval", strings.Trim(string(retrieval), `"`)})
+
+	sections := []dashboardSection{main}
+	for _, group := range [][]models.PopulatedDashboard{dashboard.Members, dashboard.Neighbours} {
+		for i := range group {
+			sections = append(sections, dashboardSection{
+				Country: group[i].Country,
+				ISOCode: group[i].ISOCode,
+				Rows:    flattenFeatures(&group[i]),
+			})
+		}
+	}
+	return sections
+}
+
+// flattenFeatures returns the enabled features of a dashboard as rows, in the order of the JSON output.
+func flattenFeatures(dashboard *models.PopulatedDashboard) []dashboardRow {
+	var rows []dashboardRow
+	data, _ := json.Marshal(dashboard.Features)
+	flattenJSON(data, "", func(feature, value string) {
+		rows = append(rows, dashboardRow{feature, value})
+	})
+	return rows
+}
+
+// flattenJSON calls emit for every value of a JSON document, with nested keys joined by dots.
+// Lists of plain values are joined into a single value.
+func flattenJSON(data []byte, prefix string, emit func(key, value string)) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	return flattenToken(dec, tok, prefix, emit)
+}
+
+// flattenToken flattens the JSON value starting with tok.
+func flattenToken(dec *json.Decoder, tok json.Token, key string, emit func(key, value string)) error {
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		if tok != nil {
+			emit(key, fmt.Sprint(tok))
+		}
+		return nil
+	}
+
+	var values []string
+	for i := 0; dec.More(); i++ {
+		childKey := strconv.Itoa(i)
+		if delim == '{' {
+			name, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			childKey = name.(string)
+		}
+		if key != "" {
+			childKey = key + "." + childKey
+		}
+
+		child, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if _, nested := child.(json.Delim); delim == '[' && !nested && child != nil {
+			values = append(values, fmt.Sprint(child))
+			continue
+		}
+		if err := flattenToken(dec, child, childKey, emit); err != nil {
+			return err
+		}
+	}
+	if len(values) > 0 {
+		emit(key, strings.Join(values, ", "))
+	}
+	_, err := dec.Token() // Closing delimiter
+	return err
+}
+
+// dashboardCSV writes one row per feature, with the country and ISO code in every row.
+func dashboardCSV(w io.Writer, sections []dashboardSection) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"country", "isoCode", "feature", "value"})
+	for _, section := range sections {
+		for _, row := range section.Rows {
+			out.Write([]string{section.Country, section.ISOCode, row.Feature, row.Value})
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// dashboardText writes a plain-text summary with one line per feature.
+func dashboardText(w io.Writer, sections []dashboardSection) error {
+	for i, section := range sections {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, sectionTitle(section))
+		for _, row := range section.Rows {
+			fmt.Fprintf(w, "  %s: %s\n", row.Feature, row.Value)
+		}
+	}
+	return nil
+}
+
+// sectionTitle returns the country name with its ISO code, e.g. "Norway (NO)".
+func sectionTitle(section dashboardSection) string {
+	if section.ISOCode == "" {
+		return section.Country
+	}
+	return fmt.Sprintf("%s (%s)", section.Country, section.ISOCode)
+}
+
+// dashboardPage is a self-contained HTML page with a table per country.
+var dashboardPage = template.Must(template.New("dashboard").Funcs(template.FuncMap{"title": sectionTitle}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{with index . 0}}{{title .}}{{end}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; color: #1f2933; }
+table { border-collapse: collapse; margin-bottom: 2rem; min-width: 24rem; }
+th, td { text-align: left; padding: 0.3rem 0.8rem; border-bottom: 1px solid #d9e2ec; }
+th { background: #f0f4f8; }
+</style>
+</head>
+<body>
+{{range .}}<h2>{{title .}}</h2>
+<table>
+<tr><th>Feature</th><th>Value</th></tr>
+{{range .Rows}}<tr><td>{{.Feature}}</td><td>{{.Value}}</td></tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`))
+
+// xmlName matches keys that can be used as XML element names as they are.
+var xmlName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9._-]*$`)
+
+// dashboardXML converts the JSON representation of a dashboard to XML, keeping the order of the fields.
+// Lists become elements with an <item> per entry, and map keys that are not valid names become <entry key="...">.
+func dashboardXML(w *bytes.Buffer, data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	w.WriteString(xml.Header)
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := xmlToken(dec, enc, tok, "dashboard"); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+// xmlToken writes the JSON value starting with tok as an XML element.
+func xmlToken(dec *json.Decoder, enc *xml.Encoder, tok json.Token, name string) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if !xmlName.MatchString(name) {
+		start = xml.StartElement{
+			Name: xml.Name{Local: "entry"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: name}},
+		}
+	}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if delim, ok := tok.(json.Delim); ok {
+		for dec.More() {
+			childName := "item"
+			if delim == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				childName = key.(string)
+			}
+			child, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if err := xmlToken(dec, enc, child, childName); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil { // Closing delimiter
+			return err
+		}
+	} else if tok != nil {
+		if err := enc.EncodeToken(xml.CharData(fmt.Sprint(tok))); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"encoding/csv"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// getDashboardAs requests a Norway dashboard with the given query and Accept header
+func getDashboardAs(t *testing.T, query, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	closeMock := startMockNordicCountriesAPI(t)
+	defer closeMock()
+
+	id := insertCountryRegistration(t, "Norway", models.Features{Capital: true, Population: true})
+	req := httptest.NewRequest(http.MethodGet, constants.Dashboards+id+query, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+
+	GetPopulatedDashboard(rec, req)
+	return rec
+}
+
+func TestGetPopulatedDashboard_CSV(t *testing.T) {
+	rec := getDashboardAs(t, "", "text/csv")
+
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("Expected 200 OK with CSV, got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	found := false
+	for _, record := range records[1:] {
+		if record[0] == "Norway" && record[2] == "capital" && record[3] == "Oslo" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected a capital row for Norway, got %v", records)
+	}
+}
+
+func TestGetPopulatedDashboard_XML(t *testing.T) {
+	rec := getDashboardAs(t, "?format=xml", "application/json")
+
+	var doc struct {
+		XMLName  xml.Name `xml:"dashboard"`
+		Country  string   `xml:"country"`
+		Features struct {
+			Capital    string `xml:"capital"`
+			Population int    `xml:"population"`
+		} `xml:"features"`
+	}
+	if err := xml.NewDecoder(rec.Body).Decode(&doc); err != nil {
+		t.Fatalf("Failed to parse XML: %v", err)
+	}
+	if doc.Country != "Norway" || doc.Features.Capital != "Oslo" || doc.Features.Population != 5000000 {
+		t.Errorf("Unexpected XML dashboard: %+v", doc)
+	}
+}
+
+func TestGetPopulatedDashboard_HTMLAndText(t *testing.T) {
+	rec := getDashboardAs(t, "", "text/html, text/plain;q=0.5")
+	if body := rec.Body.String(); !strings.Contains(body, "<h2>Norway (NO)</h2>") {
+		t.Errorf("Expected an HTML page for Norway, got %s", body)
+	}
+
+	rec = getDashboardAs(t, "?format=text", "")
+	if body := rec.Body.String(); !strings.Contains(body, "capital: Oslo") {
+		t.Errorf("Expected a plain-text summary, got %s", body)
+	}
+}
+
+func TestGetPopulatedDashboard_NotAcceptable(t *testing.T) {
+	for _, tc := range []struct{ query, accept string }{{"", "image/png"}, {"?format=pdf", ""}} {
+		req := httptest.NewRequest(http.MethodGet, constants.Dashboards+"any-id"+tc.query, nil)
+		req.Header.Set("Accept", tc.accept)
+		rec := httptest.NewRecorder()
+
+		GetPopulatedDashboard(rec, req)
+
+		if rec.Code != http.StatusNotAcceptable {
+			t.Errorf("%+v: expected 406 Not Acceptable, got %d", tc, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/*
+dashboardLookups memoizes external API lookups while dashboards are populated for a single request.
+Neighbours and compared registrations often share countries, coordinates or currencies,
+and each of them is then only fetched once. It is safe for concurrent use.
+*/
+type dashboardLookups struct {
+	mutex     sync.Mutex
+	countries map[string]*models.CountryInfo
+	weather   map[string][2]float64
+	rates     map[string]map[string]float64
+}
+
+// newDashboardLookups creates an empty lookup cache for one request.
+func newDashboardLookups() *dashboardLookups {
+	return &dashboardLookups{
+		countries: make(map[string]*models.CountryInfo),
+		weather:   make(map[string][2]float64),
+		rates:     make(map[string]map[string]float64),
+	}
+}
+
+// countryByName returns country info by name.
+func (l *dashboardLookups) countryByName(name string) (*models.CountryInfo, error) {
+	key := "name:" + strings.ToLower(name)
+	if info, ok := l.cachedCountry(key); ok {
+		return info, nil
+	}
+	info, err := services.GetCountryInfo(name)
+	if err != nil {
+		return nil, err
+	}
+	l.remember(key, info)
+	return info, nil
+}
+
+// countryByCode returns country info by ISO code in any of its forms.
+func (l *dashboardLookups) countryByCode(code string) (*models.CountryInfo, error) {
+	key := "code:" + strings.ToUpper(code)
+	if info, ok := l.cachedCountry(key); ok {
+		return info, nil
+	}
+	info, err := services.GetCountryInfoByCode(code)
+	if err != nil {
+		return nil, err
+	}
+	l.remember(key, info)
+	return info, nil
+}
+
+// cachedCountry returns country info stored under the lookup key.
+func (l *dashboardLookups) cachedCountry(key string) (*models.CountryInfo, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	info, ok := l.countries[key]
+	return info, ok
+}
+
+// remember stores country info under the lookup key and all of its ISO codes.
+func (l *dashboardLookups) remember(key string, info *models.CountryInfo) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.countries[key] = info
+	for _, code := range []string{info.ISOCode, info.ISOAlpha3, info.ISONumeric} {
+		if code != "" {
+			l.countries["code:"+strings.ToUpper(code)] = info
+		}
+	}
+}
+
+// weatherAt returns the average temperature and precipitation at the given coordinates.
+func (l *dashboardLookups) weatherAt(lat, lon float64) (float64, float64, error) {
+	key := fmt.Sprintf("%.2f,%.2f", lat, lon)
+	l.mutex.Lock()
+	w, ok := l.weather[key]
+	l.mutex.Unlock()
+	if ok {
+		return w[0], w[1], nil
+	}
+	temp, precip, err := services.GetWeatherData(lat, lon)
+	if err != nil {
+		return 0, 0, err
+	}
+	l.mutex.Lock()
+	l.weather[key] = [2]float64{temp, precip}
+	l.mutex.Unlock()
+	return temp, precip, nil
+}
+
+// exchangeRates returns the rates from the base currency to the target currencies.
+func (l *dashboardLookups) exchangeRates(base string, targets []string) (map[string]float64, error) {
+	sorted := append([]string(nil), targets...)
+	sort.Strings(sorted)
+	key := base + ":" + strings.Join(sorted, ",")
+	l.mutex.Lock()
+	cached, ok := l.rates[key]
+	l.mutex.Unlock()
+	if ok {
+		return cached, nil
+	}
+	rates, err := services.GetExchangeRates(base, targets)
+	if err != nil {
+		return nil, err
+	}
+	l.mutex.Lock()
+	l.rates[key] = rates
+	l.mutex.Unlock()
+	return rates, nil
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/models"
+	"fmt"
+	"net/http"
+)
+
+// featureToggles enables a feature by the name used in the registration's features.
+var featureToggles = map[string]func(*models.Features){
+	"temperature":       func(f *models.Features) { f.Temperature = true },
+	"precipitation":     func(f *models.Features) { f.Precipitation = true },
+	"capital":           func(f *models.Features) { f.Capital = true },
+	"coordinates":       func(f *models.Features) { f.Coordinates = true },
+	"population":        func(f *models.Features) { f.Population = true },
+	"area":              func(f *models.Features) { f.Area = true },
+	"languages":         func(f *models.Features) { f.Languages = true },
+	"borders":           func(f *models.Features) { f.Borders = true },
+	"timezones":         func(f *models.Features) { f.Timezones = true },
+	"region":            func(f *models.Features) { f.Region = true },
+	"flag":              func(f *models.Features) { f.Flag = true },
+	"callingCode":       func(f *models.Features) { f.CallingCode = true },
+	"drivingSide":       func(f *models.Features) { f.DrivingSide = true },
+	"topLevelDomain":    func(f *models.Features) { f.TopLevelDomain = true },
+	"populationDensity": func(f *models.Features) { f.PopulationDensity = true },
+	"localTime":         func(f *models.Features) { f.LocalTime = true },
+	"daylight":          func(f *models.Features) { f.Daylight = true },
+}
+
+// targetCurrenciesField is the field name that keeps the target currencies when fields are selected.
+const targetCurrenciesField = "targetCurrencies"
+
+// applyFeatureOverrides changes the features of a loaded registration for a single request.
+// ?fields=temperature,capital returns exactly those features, whether they are enabled in the registration or not,
+// and ?currencies=EUR,JPY replaces the target currencies. The stored registration is not changed.
+func applyFeatureOverrides(r *http.Request, config *models.Registration) error {
+	query := r.URL.Query()
+
+	if fields := splitIDs(query.Get(constants.FieldsParam)); len(fields) > 0 {
+		var selected models.Features
+		for _, field := range fields {
+			if field == targetCurrenciesField {
+				selected.TargetCurrencies = config.Features.TargetCurrencies
+				continue
+			}
+			enable, ok := featureToggles[field]
+			if !ok {
+				return fmt.Errorf("%s: %s", errorMessages.UnknownField, field)
+			}
+			enable(&selected)
+		}
+		config.Features = selected
+	}
+
+	if currencies := splitIDs(query.Get(constants.CurrenciesParam)); len(currencies) > 0 {
+		config.Features.TargetCurrencies = currencies
+		if err := validateCurrencies(&config.Features); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// getDashboardFeatures requests a dashboard and decodes its features
+func getDashboardFeatures(t *testing.T, id, query string) (int, models.DashboardFeatures) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, constants.Dashboards+id+query, nil)
+	rec := httptest.NewRecorder()
+
+	GetPopulatedDashboard(rec, req)
+
+	if rec.Code != http.StatusOK {
+		return rec.Code, models.DashboardFeatures{}
+	}
+	var dashboard models.PopulatedDashboard
+	if err := json.NewDecoder(rec.Body).Decode(&dashboard); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return rec.Code, dashboard.Features
+}
+
+func TestGetPopulatedDashboard_FieldsNarrowAndExtend(t *testing.T) {
+	closeMock := startMockNordicCountriesAPI(t)
+	defer closeMock()
+
+	id := insertCountryRegistration(t, "Norway", models.Features{Capital: true, Population: true})
+
+	_, features := getDashboardFeatures(t, id, "?fields=capital")
+	if features.Capital != "Oslo" || features.Population != 0 {
+		t.Errorf("Expected only the capital, got %+v", features)
+	}
+
+	// Area is not enabled in the registration, but is returned when asked for explicitly
+	_, features = getDashboardFeatures(t, id, "?fields=capital,area")
+	if features.Capital != "Oslo" || features.Area != 323802 || features.Population != 0 {
+		t.Errorf("Expected capital and area, got %+v", features)
+	}
+}
+
+func TestGetPopulatedDashboard_UnknownField(t *testing.T) {
+	id := insertCountryRegistration(t, "Norway", models.Features{Capital: true})
+
+	if code, _ := getDashboardFeatures(t, id, "?fields=capital,humidity"); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 Bad Request, got %d", code)
+	}
+}
+
+func TestGetPopulatedDashboard_CurrenciesOverride(t *testing.T) {
+	closeCurrencies := startMockCurrencyCatalogueAPI(t)
+	defer closeCurrencies()
+	closeCountries := startMockNordicCountriesAPI(t)
+	defer closeCountries()
+
+	id := insertCountryRegistration(t, "Norway", models.Features{TargetCurrencies: []string{"USD"}})
+
+	_, features := getDashboardFeatures(t, id, "?currencies=sek")
+	if len(features.TargetCurrencies) != 1 || features.TargetCurrencies["SEK"] == 0 {
+		t.Errorf("Expected only the SEK rate, got %+v", features.TargetCurrencies)
+	}
+
+	if code, _ := getDashboardFeatures(t, id, "?currencies=XYZ"); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 Bad Request for an unsupported currency, got %d", code)
+	}
+}
@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/models"
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// svgOptions controls how a dashboard is rendered as SVG.
+type svgOptions struct {
+	badge  bool     // Render a compact badge for a single metric instead of the full card
+	metric string   // Metric shown on the badge
+	theme  svgTheme // Colours of the card or badge
+
+	// Features enabled for the dashboard, which tell a value of 0 (e.g. a dry day) from a feature that is off
+	enabled models.Features
+}
+
+// svgTheme holds the colours of a card or badge.
+type svgTheme struct {
+	Background string
+	Text       string
+	Muted      string
+	Border     string
+	Accent     string
+}
+
+// svgThemes are the themes that can be chosen with ?theme=.
+var svgThemes = map[string]svgTheme{
+	"light": {Background: "#ffffff", Text: "#1f2933", Muted: "#52606d", Border: "#d9e2ec", Accent: "#2680c2"},
+	"dark":  {Background: "#1f2933", Text: "#f5f7fa", Muted: "#9aa5b1", Border: "#3e4c59", Accent: "#47a3f3"},
+}
+
+// badgeMetrics are the metrics a badge can show, besides the target currencies.
+var badgeMetrics = []string{"temperature", "precipitation", "capital", "population", "area", "populationDensity"}
+
+// parseSVGOptions reads the theme and, for badges, the metric from the query.
+func parseSVGOptions(r *http.Request, badge bool) (svgOptions, error) {
+	query := r.URL.Query()
+	name := strings.ToLower(query.Get(constants.ThemeParam))
+	if name == "" {
+		name = "light"
+	}
+	theme, ok := svgThemes[name]
+	if !ok {
+		return svgOptions{}, fmt.Errorf("%s: %s", errorMessages.UnknownTheme, name)
+	}
+
+	options := svgOptions{badge: badge, theme: theme}
+	if badge {
+		options.metric = query.Get(constants.MetricParam)
+		if options.metric == "" {
+			options.metric = "temperature"
+		}
+		if !isBadgeMetric(options.metric) {
+			return svgOptions{}, fmt.Errorf("%s: %s", errorMessages.UnknownMetric, options.metric)
+		}
+	}
+	return options, nil
+}
+
+// isBadgeMetric reports whether a badge can show the metric. Currency codes show the exchange rate.
+func isBadgeMetric(metric string) bool {
+	for _, m := range badgeMetrics {
+		if m == metric {
+			return true
+		}
+	}
+	return len(metric) == 3 && strings.ToUpper(metric) == metric
+}
+
+// writeDashboardSVG renders a dashboard as an SVG card or badge.
+func writeDashboardSVG(w http.ResponseWriter, options svgOptions, dashboard *models.PopulatedDashboard) error {
+	var body bytes.Buffer
+	var err error
+	if options.badge {
+		err = renderBadge(&body, options, dashboard)
+	} else {
+		err = renderCard(&body, options, dashboard)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "no-cache")
+	_, err = w.Write(body.Bytes())
+	return err
+}
+
+// cardLine is a line of text on a dashboard card.
+type cardLine struct {
+	Y    int
+	Text string
+}
+
+// cardData is the input of the card template.
+type cardData struct {
+	Theme  svgTheme
+	Title  string
+	Height int
+	Lines  []cardLine
+	Footer cardLine
+}
+
+// Card layout in pixels
+const (
+	cardWidth      = 320
+	cardLineHeight = 22
+	cardTop        = 64
+)
+
+// renderCard draws the country name and flag, temperature, precipitation and currency rates.
+func renderCard(buf *bytes.Buffer, options svgOptions, dashboard *models.PopulatedDashboard) error {
+	f := dashboard.Features
+	title := dashboard.Country
+	if emoji := flagEmoji(dashboard.ISOCode); emoji != "" {
+		title = emoji + " " + title
+	}
+
+	var texts, weather []string
+	if options.enabled.Temperature {
+		weather = append(weather, fmt.Sprintf("%.1f °C", f.Temperature))
+	}
+	if options.enabled.Precipitation {
+		weather = append(weather, fmt.Sprintf("%.1f mm", f.Precipitation))
+	}
+	if len(weather) > 0 {
+		texts = append(texts, strings.Join(weather, "  ·  "))
+	}
+
+	currencies := make([]string, 0, len(f.TargetCurrencies))
+	for currency := range f.TargetCurrencies {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		texts = append(texts, fmt.Sprintf("%s %s", currency, formatRate(f.TargetCurrencies[currency])))
+	}
+
+	data := cardData{Theme: options.theme, Title: title}
+	for i, text := range texts {
+		data.Lines = append(data.Lines, cardLine{Y: cardTop + i*cardLineHeight, Text: text})
+	}
+	data.Height = cardTop + len(texts)*cardLineHeight + 12
+	retrieval, _ := dashboard.LastRetrieval.MarshalJSON()
+	data.Footer = cardLine{Y: data.Height - 12, Text: strings.Trim(string(retrieval), `"`)}
+	data.Height += 8
+	return cardTemplate.Execute(buf, data)
+}
+
+// cardTemplate draws a dashboard card. html/template escapes the text, which is valid in SVG as well.
+var cardTemplate = template.Must(template.New("card").Parse(
+	`<svg xmlns="http://www.w3.org/2000/svg" width="320" height="{{.Height}}" viewBox="0 0 320 {{.Height}}" role="img" aria-label="{{.Title}}">
+<rect x="0.5" y="0.5" width="319" height="{{.Height}}" rx="8" fill="{{.Theme.Background}}" stroke="{{.Theme.Border}}"/>
+<g font-family="system-ui, -apple-system, Segoe UI, sans-serif">
+<text x="16" y="32" font-size="18" font-weight="600" fill="{{.Theme.Text}}">{{.Title}}</text>
+<line x1="16" y1="44" x2="304" y2="44" stroke="{{.Theme.Accent}}" stroke-width="2"/>
+{{range .Lines}}<text x="16" y="{{.Y}}" font-size="14" fill="{{$.Theme.Text}}">{{.Text}}</text>
+{{end}}<text x="16" y="{{.Footer.Y}}" font-size="10" fill="{{.Theme.Muted}}">{{.Footer.Text}}</text>
+</g>
+</svg>
+`))
+
+// badgeData is the input of the badge template.
+type badgeData struct {
+	Theme      svgTheme
+	Label      string
+	Value      string
+	LabelWidth int
+	ValueWidth int
+}
+
+// renderBadge draws a single metric as a two-part badge, e.g. "Norway temperature | 12.3 °C".
+func renderBadge(buf *bytes.Buffer, options svgOptions, dashboard *models.PopulatedDashboard) error {
+	data := badgeData{
+		Theme: options.theme,
+		Label: fmt.Sprintf("%s %s", dashboard.Country, options.metric),
+		Value: badgeValue(options.metric, options.enabled, dashboard.Features),
+	}
+	data.LabelWidth = textWidth(data.Label)
+	data.ValueWidth = textWidth(data.Value)
+	return badgeTemplate.Execute(buf, data)
+}
+
+// badgeValue formats a metric of the dashboard. Metrics that are not enabled in the registration show "n/a".
+func badgeValue(metric string, enabled models.Features, f models.DashboardFeatures) string {
+	value := ""
+	switch metric {
+	case "temperature":
+		if enabled.Temperature {
+			value = fmt.Sprintf("%.1f °C", f.Temperature)
+		}
+	case "precipitation":
+		if enabled.Precipitation {
+			value = fmt.Sprintf("%.1f mm", f.Precipitation)
+		}
+	case "capital":
+		value = f.Capital
+	case "population":
+		if enabled.Population {
+			value = strconv.Itoa(f.Population)
+		}
+	case "area":
+		if enabled.Area {
+			value = fmt.Sprintf("%.0f km²", f.Area)
+		}
+	case "populationDensity":
+		if enabled.PopulationDensity {
+			value = fmt.Sprintf("%.1f /km²", f.PopulationDensity)
+		}
+	default:
+		if rate, ok := f.TargetCurrencies[metric]; ok {
+			value = formatRate(rate)
+		}
+	}
+	if value == "" {
+		return "n/a"
+	}
+	return value
+}
+
+// flagEmoji returns the flag of an ISO alpha-2 code as a pair of regional indicator symbols, or "" for other codes.
+func flagEmoji(isoCode string) string {
+	if len(isoCode) != 2 {
+		return ""
+	}
+	var flag strings.Builder
+	for _, c := range strings.ToUpper(isoCode) {
+		if c < 'A' || c > 'Z' {
+			return ""
+		}
+		flag.WriteRune(0x1F1E6 + c - 'A')
+	}
+	return flag.String()
+}
+
+// formatRate formats an exchange rate with four significant decimals for small rates.
+func formatRate(rate float64) string {
+	if rate < 1 {
+		return strconv.FormatFloat(rate, 'f', 4, 64)
+	}
+	return strconv.FormatFloat(rate, 'f', 2, 64)
+}
+
+// textWidth estimates the width of badge text in pixels, with padding on both sides.
+func textWidth(text string) int {
+	return utf8.RuneCountInString(text)*7 + 12
+}
+
+// badgeTemplate draws a badge with the label on the left and the value on the right.
+var badgeTemplate = template.Must(template.New("badge").Funcs(template.FuncMap{
+	"add":  func(a, b int) int { return a + b },
+	"half": func(a int) int { return a / 2 },
+}).Parse(
+	`<svg xmlns="http://www.w3.org/2000/svg" width="{{add .LabelWidth .ValueWidth}}" height="20" role="img" aria-label="{{.Label}}: {{.Value}}">
+<rect width="{{.LabelWidth}}" height="20" rx="3" fill="{{.Theme.Border}}"/>
+<rect x="{{.LabelWidth}}" width="{{.ValueWidth}}" height="20" rx="3" fill="{{.Theme.Accent}}"/>
+<g font-family="Verdana, DejaVu Sans, sans-serif" font-size="11" text-anchor="middle">
+<text x="{{half .LabelWidth}}" y="14" fill="{{.Theme.Text}}">{{.Label}}</text>
+<text x="{{add .LabelWidth (half .ValueWidth)}}" y="14" fill="#ffffff">{{.Value}}</text>
+</g>
+</svg>
+`))
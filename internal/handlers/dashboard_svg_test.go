@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetDashboardSVGCard(t *testing.T) {
+	closeMock := startMockNordicCountriesAPI(t)
+	defer closeMock()
+
+	id := insertCountryRegistration(t, "Norway", models.Features{Capital: true})
+	req := httptest.NewRequest(http.MethodGet, constants.Dashboards+id+".svg?theme=dark", nil)
+	rec := httptest.NewRecorder()
+
+	GetPopulatedDashboard(rec, req)
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/svg+xml" {
+		t.Fatalf("Expected 200 OK with SVG, got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "Norway") || !strings.Contains(body, svgThemes["dark"].Background) {
+		t.Errorf("Expected a dark card for Norway, got %s", body)
+	}
+	var doc struct{}
+	if err := xml.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Errorf("Expected well-formed SVG: %v", err)
+	}
+}
+
+func TestGetDashboardSVGBadge(t *testing.T) {
+	closeMock := startMockNordicCountriesAPI(t)
+	defer closeMock()
+
+	id := insertCountryRegistration(t, "Norway", models.Features{Population: true})
+	req := httptest.NewRequest(http.MethodGet, constants.Dashboards+id+"/badge.svg?metric=population", nil)
+	rec := httptest.NewRecorder()
+
+	GetPopulatedDashboard(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", rec.Code)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, ">5000000<") {
+		t.Errorf("Expected the population on the badge, got %s", body)
+	}
+}
+
+func TestGetDashboardSVG_InvalidOptions(t *testing.T) {
+	for _, path := range []string{"any-id.svg?theme=neon", "any-id/badge.svg?metric=humidity"} {
+		req := httptest.NewRequest(http.MethodGet, constants.Dashboards+path, nil)
+		rec := httptest.NewRecorder()
+
+		GetPopulatedDashboard(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400 Bad Request, got %d", path, rec.Code)
+		}
+	}
+}
+
+func TestBadgeValue_ZeroIsAValue(t *testing.T) {
+	enabled := models.Features{Temperature: true, Precipitation: true}
+	if got := badgeValue("precipitation", enabled, models.DashboardFeatures{}); got != "0.0 mm" {
+		t.Errorf("Expected a dry day to show 0.0 mm, got %q", got)
+	}
+	if got := badgeValue("temperature", enabled, models.DashboardFeatures{}); got != "0.0 °C" {
+		t.Errorf("Expected 0.0 °C, got %q", got)
+	}
+	if got := badgeValue("population", enabled, models.DashboardFeatures{}); got != "n/a" {
+		t.Errorf("Expected n/a for a feature that is not enabled, got %q", got)
+	}
+}
+
+func TestRenderCard_FlagFromISOCode(t *testing.T) {
+	var buf bytes.Buffer
+	dashboard := &models.PopulatedDashboard{Country: "Norway", ISOCode: "NO"}
+	if err := renderCard(&buf, svgOptions{theme: svgThemes["light"]}, dashboard); err != nil {
+		t.Fatalf("Failed to render card: %v", err)
+	}
+	if !strings.Contains(buf.String(), "🇳🇴 Norway") {
+		t.Errorf("Expected the flag emoji in the title, got %s", buf.String())
+	}
+}
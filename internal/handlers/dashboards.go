@@ -7,13 +7,27 @@ import (
 	"Country-Dashboard-Service/internal/models"
 	"Country-Dashboard-Service/internal/services"
 	"Country-Dashboard-Service/internal/utils"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"math"
 	"net/http"
 	"strings"
 	"time"
 )
 
+// dashboardError is returned when a dashboard could not be populated.
+// It carries the HTTP status and message to send to the client.
+type dashboardError struct {
+	status  int
+	message string
+	err     error
+}
+
+func (e *dashboardError) Error() string {
+	return fmt.Sprintf("%s: %v", e.message, e.err)
+}
+
 // GetPopulatedDashboard handles GET requests for a populated dashboard by ID
 func GetPopulatedDashboard(w http.ResponseWriter, r *http.Request) {
 	// Extract ID from URL path
@@ -24,6 +38,29 @@ func GetPopulatedDashboard(w http.ResponseWriter, r *http.Request) {
 	}
 	id := parts[4]
 
+	// Side-by-side comparison of several registrations
+	if id == constants.CompareSegment {
+		compareDashboards(w, r)
+		return
+	}
+
+	// Pick the representation before doing any work, so unsupported formats fail fast.
+	// Embeddable SVG cards and badges have their own paths: /dashboards/{id}.svg and /dashboards/{id}/badge.svg
+	var svg *svgOptions
+	format, ok := negotiateDashboardFormat(r)
+	if cardID, isCard := strings.CutSuffix(id, constants.SVGSuffix); isCard || (len(parts) > 5 && parts[5] == constants.BadgeSegment) {
+		options, err := parseSVGOptions(r, !isCard)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		id, svg, ok = cardID, &options, true
+	}
+	if !ok {
+		http.Error(w, errorMessages.NotAcceptable, http.StatusNotAcceptable)
+		return
+	}
+
 	// Load full registration (from Firestore)
 	config, err := firestore.GetDashboardConfigByID(id)
 	if err != nil {
@@ -32,23 +69,119 @@ func GetPopulatedDashboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The stored registration goes in the webhook payloads, not the one with the overrides of this request
+	stored := *config
+
+	// Narrow or extend the features and override the target currencies for this request only
+	if err := applyFeatureOverrides(r, config); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Timestamps are written in the zone and format asked for, or the one stored in the registration
+	settings, err := requestTimeSettings(r, config)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if svg != nil {
+		svg.enabled = config.Features
+	}
+
+	// Populate the dashboard from the external APIs
+	response, err := populateDashboard(config, newDashboardLookups())
+	if err != nil {
+		writeDashboardError(w, err)
+		return
+	}
+
+	// Trigger webhooks for INVOKE event, for every member country of multi-country registrations
+	services.PublishInvokeEvents(dashboardCountries(config, response), models.WebhookEventData{Registration: &stored, Dashboard: response})
+
+	// Country names follow the Accept-Language header
+	localizeDashboard(response, utils.PreferredLanguages(r))
+	applyTimeSettings(response, settings)
+
+	// Send response
+	if svg != nil {
+		err = writeDashboardSVG(w, *svg, response)
+	} else {
+		err = writeDashboard(w, format, response)
+	}
+	if err != nil {
+		log.Printf("Failed to write dashboard %s: %v", id, err)
+	}
+}
+
+// writeDashboardError sends the status and message of a failed dashboard population.
+func writeDashboardError(w http.ResponseWriter, err error) {
+	var dashErr *dashboardError
+	if errors.As(err, &dashErr) {
+		http.Error(w, dashErr.message, dashErr.status)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// populateDashboard fetches country, weather and currency data for a registration
+// and builds the dashboard with the features enabled in it.
+// Lookups are shared between dashboards populated in the same request.
+func populateDashboard(config *models.Registration, lookups *dashboardLookups) (*models.PopulatedDashboard, error) {
+	// Multi-country and region registrations are populated per member country
+	if len(config.Members) > 0 {
+		return populateAggregate(config, lookups)
+	}
+
 	// Get country data
-	countryInfo, err := services.GetCountryInfo(config.Country)
+	countryInfo, err := lookups.countryByName(config.Country)
 	if err != nil {
 		fmt.Println("Failed to fetch country data:", err) // Debug
-		http.Error(w, errorMessages.CountryNotRecognized, http.StatusBadGateway)
-		return
+		return nil, &dashboardError{http.StatusBadGateway, errorMessages.CountryNotRecognized, err}
+	}
+
+	dashboard, err := buildDashboard(countryInfo, config.Features, lookups)
+	if err != nil {
+		return nil, err
+	}
+
+	// Populate the same features for every bordering country if requested
+	if config.Neighbours {
+		dashboard.Neighbours = populateNeighbours(countryInfo, config.Features, lookups)
+	}
+	return dashboard, nil
+}
+
+// populateNeighbours builds a dashboard for each country bordering the given one.
+// Neighbours that can't be populated are left out rather than failing the whole dashboard.
+func populateNeighbours(countryInfo *models.CountryInfo, enabled models.Features, lookups *dashboardLookups) []models.PopulatedDashboard {
+	neighbours := make([]models.PopulatedDashboard, 0, len(countryInfo.Borders))
+	for _, code := range countryInfo.Borders {
+		neighbourInfo, err := lookups.countryByCode(code)
+		if err != nil {
+			log.Printf("Skipping neighbour %s of %s: %v", code, countryInfo.Name, err)
+			continue
+		}
+		neighbour, err := buildDashboard(neighbourInfo, enabled, lookups)
+		if err != nil {
+			log.Printf("Skipping neighbour %s of %s: %v", code, countryInfo.Name, err)
+			continue
+		}
+		neighbours = append(neighbours, *neighbour)
 	}
+	return neighbours
+}
 
+// buildDashboard fetches weather and currency data for a country and builds the enabled features.
+func buildDashboard(countryInfo *models.CountryInfo, enabled models.Features, lookups *dashboardLookups) (*models.PopulatedDashboard, error) {
 	// Get weather data if requested
 	var temperature float64
 	var precipitation float64
-	if config.Features.Temperature || config.Features.Precipitation {
-		temp, precip, err := services.GetWeatherData(countryInfo.Latitude, countryInfo.Longitude)
+	if enabled.Temperature || enabled.Precipitation {
+		temp, precip, err := lookups.weatherAt(countryInfo.Latitude, countryInfo.Longitude)
 		if err != nil {
 			fmt.Println("Failed to fetch weather data:", err) // Debug
-			http.Error(w, errorMessages.APIFailed, http.StatusBadGateway)
-			return
+			return nil, &dashboardError{http.StatusBadGateway, errorMessages.APIFailed, err}
 		}
 		temperature = temp
 		precipitation = precip
@@ -56,12 +189,11 @@ func GetPopulatedDashboard(w http.ResponseWriter, r *http.Request) {
 
 	// Get currency rates if requested
 	targetCurrencies := make(map[string]float64)
-	if len(config.Features.TargetCurrencies) > 0 {
-		rates, err := services.GetExchangeRates(countryInfo.Currency, config.Features.TargetCurrencies)
+	if len(enabled.TargetCurrencies) > 0 {
+		rates, err := lookups.exchangeRates(countryInfo.Currency, enabled.TargetCurrencies)
 		if err != nil {
 			fmt.Println("Failed to fetch currency data:", err) // Debug
-			http.Error(w, errorMessages.APIFailed, http.StatusBadGateway)
-			return
+			return nil, &dashboardError{http.StatusBadGateway, errorMessages.APIFailed, err}
 		}
 		targetCurrencies = rates
 	}
@@ -69,43 +201,100 @@ func GetPopulatedDashboard(w http.ResponseWriter, r *http.Request) {
 	// Build features object based on selected options
 	features := models.DashboardFeatures{}
 
-	if config.Features.Temperature {
+	if enabled.Temperature {
 		features.Temperature = temperature
 	}
-	if config.Features.Precipitation {
+	if enabled.Precipitation {
 		features.Precipitation = precipitation
 	}
-	if config.Features.Capital {
+	if enabled.Capital {
 		features.Capital = countryInfo.Capital
 	}
-	if config.Features.Coordinates {
+	if enabled.Coordinates {
 		features.Coordinates = models.Coordinates{
 			Latitude:  countryInfo.Latitude,
 			Longitude: countryInfo.Longitude,
 		}
 	}
-	if config.Features.Population {
+	if enabled.Population {
 		features.Population = countryInfo.Population
 	}
-	if config.Features.Area {
+	if enabled.Area {
 		features.Area = countryInfo.Area
 	}
-	if len(config.Features.TargetCurrencies) > 0 {
+	if len(enabled.TargetCurrencies) > 0 {
 		features.TargetCurrencies = targetCurrencies
 	}
+	if enabled.Languages {
+		features.Languages = countryInfo.Languages
+	}
+	if enabled.Borders {
+		features.Borders = countryInfo.Borders
+	}
+	if enabled.Timezones {
+		features.Timezones = countryInfo.Timezones
+	}
+	if enabled.Region {
+		features.Region = countryInfo.Region
+		features.Subregion = countryInfo.Subregion
+	}
+	if enabled.Flag {
+		features.Flag = &models.Flag{
+			Emoji: countryInfo.FlagEmoji,
+			PNG:   countryInfo.FlagPNG,
+			SVG:   countryInfo.FlagSVG,
+		}
+	}
+	if enabled.CallingCode {
+		features.CallingCodes = countryInfo.CallingCodes
+	}
+	if enabled.DrivingSide {
+		features.DrivingSide = countryInfo.DrivingSide
+	}
+	if enabled.TopLevelDomain {
+		features.TopLevelDomains = countryInfo.TopLevelDomains
+	}
+
+	// Computed features, derived from the data fetched above
+	now := time.Now()
+	if enabled.PopulationDensity && countryInfo.Area > 0 {
+		features.PopulationDensity = math.Round(float64(countryInfo.Population)/countryInfo.Area*100) / 100
+	}
+	// The capital's timezone is also used to show sunrise and sunset in local time
+	tzName, loc, hasTZ := utils.CapitalTimezone(countryInfo.ISOCode, countryInfo.Timezones, countryInfo.CapitalLon)
+	if !hasTZ {
+		loc = time.UTC
+	}
+	if enabled.LocalTime && hasTZ {
+		features.LocalTime = &models.LocalTime{
+			Time:     now.In(loc).Format(time.RFC3339),
+			Timezone: tzName,
+		}
+	}
+	if enabled.Daylight {
+		features.Daylight = daylightAt(countryInfo.Latitude, countryInfo.Longitude, now.In(loc), loc)
+	}
 
 	// Build final response
-	response := models.PopulatedDashboard{
+	return &models.PopulatedDashboard{
 		Country:       countryInfo.Name,
 		ISOCode:       countryInfo.ISOCode,
-		LastRetrieval: utils.CustomTime{Time: time.Now()},
+		LastRetrieval: utils.CustomTime{Time: now},
 		Features:      features,
-	}
-
-	// Trigger webhooks for INVOKE event
-	services.TriggerWebhookEvent(constants.EventInvoke, countryInfo.ISOCode)
+	}, nil
+}
 
-	// Send response
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+// daylightAt computes sunrise, sunset and day length for the date of t, formatted in the given location.
+func daylightAt(lat, lon float64, t time.Time, loc *time.Location) *models.Daylight {
+	day := utils.ComputeSolarDay(lat, lon, t)
+	daylight := &models.Daylight{
+		DayLength:  utils.FormatDuration(day.DayLength),
+		PolarDay:   day.PolarDay,
+		PolarNight: day.PolarNight,
+	}
+	if !day.PolarDay && !day.PolarNight {
+		daylight.Sunrise = day.Sunrise.In(loc).Format(time.RFC3339)
+		daylight.Sunset = day.Sunset.In(loc).Format(time.RFC3339)
+	}
+	return daylight
 }
@@ -58,9 +58,7 @@ func TestGetPopulatedDashboard(t *testing.T) {
 	defer mockCountries.Close()
 
 	// Override the actual constant temporarily
-	oldCountriesAPI := constants.RestCountriesAPI
-	constants.RestCountriesAPI = mockCountries.URL
-	defer func() { constants.RestCountriesAPI = oldCountriesAPI }()
+	useMockAPI(t, &constants.RestCountriesAPI, mockCountries.URL)
 
 	// Set up mock weather API
 	mockWeather := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -74,7 +72,7 @@ func TestGetPopulatedDashboard(t *testing.T) {
 		w.Write([]byte(resp))
 	}))
 	defer mockWeather.Close()
-	constants.OpenMeteoAPI = mockWeather.URL
+	useMockAPI(t, &constants.OpenMeteoAPI, mockWeather.URL)
 
 	// Set up mock currency API
 	mockCurrency := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -89,7 +87,7 @@ func TestGetPopulatedDashboard(t *testing.T) {
 		w.Write([]byte(resp))
 	}))
 	defer mockCurrency.Close()
-	constants.CurrencyAPI = mockCurrency.URL + "/"
+	useMockAPI(t, &constants.CurrencyAPI, mockCurrency.URL+"/")
 
 	// Insert test registration
 	id := insertTestRegistration(t)
@@ -162,7 +160,7 @@ func TestGetPopulatedDashboard_CountryInfoFailure(t *testing.T) {
 		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
 	}))
 	defer mockCountries.Close()
-	constants.RestCountriesAPI = mockCountries.URL
+	useMockAPI(t, &constants.RestCountriesAPI, mockCountries.URL)
 
 	// Insert test registration
 	id := insertTestRegistration(t)
@@ -186,7 +184,7 @@ func TestGetPopulatedDashboard_WeatherAPIError(t *testing.T) {
 		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
 	}))
 	defer mockWeather.Close()
-	constants.OpenMeteoAPI = mockWeather.URL
+	useMockAPI(t, &constants.OpenMeteoAPI, mockWeather.URL)
 
 	// Insert test registration
 	id := insertTestRegistration(t)
@@ -210,7 +208,7 @@ func TestGetPopulatedDashboard_CurrencyAPIError(t *testing.T) {
 		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
 	}))
 	defer mockCurrency.Close()
-	constants.CurrencyAPI = mockCurrency.URL
+	useMockAPI(t, &constants.CurrencyAPI, mockCurrency.URL)
 
 	// Insert test registration
 	id := insertTestRegistration(t)
@@ -226,3 +224,200 @@ func TestGetPopulatedDashboard_CurrencyAPIError(t *testing.T) {
 		t.Errorf("Expected status 502 Bad Gateway, got %d", rec.Code)
 	}
 }
+
+// Test that the extended country facts are shown when enabled
+func TestGetPopulatedDashboard_ExtendedFacts(t *testing.T) {
+	mockCountries := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := `[
+			{
+				"name": { "common": "Norway" },
+				"cca2": "NO",
+				"latlng": [60.0, 10.0],
+				"currencies": { "NOK": { "name": "Norwegian krone" } },
+				"languages": { "nno": "Norwegian Nynorsk", "nob": "Norwegian Bokmål" },
+				"borders": ["FIN", "SWE", "RUS"],
+				"timezones": ["UTC+01:00"],
+				"region": "Europe",
+				"subregion": "Northern Europe",
+				"flag": "🇳🇴",
+				"flags": { "png": "https://flagcdn.com/w320/no.png", "svg": "https://flagcdn.com/no.svg" },
+				"idd": { "root": "+4", "suffixes": ["7"] },
+				"car": { "side": "right" },
+				"tld": [".no"]
+			}
+		]`
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(resp))
+	}))
+	defer mockCountries.Close()
+	useMockAPI(t, &constants.RestCountriesAPI, mockCountries.URL)
+
+	reg := models.Registration{
+		Country: "Norway",
+		IsoCode: "NO",
+		Features: models.Features{
+			Languages:      true,
+			Borders:        true,
+			Timezones:      true,
+			Region:         true,
+			Flag:           true,
+			CallingCode:    true,
+			DrivingSide:    true,
+			TopLevelDomain: true,
+		},
+	}
+	docRef, _, err := firestore.Client.Collection("registrations").Add(context.Background(), reg)
+	if err != nil {
+		t.Fatalf("Failed to insert test registration: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/dashboard/v1/dashboards/"+docRef.ID, nil)
+	rec := httptest.NewRecorder()
+
+	GetPopulatedDashboard(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", rec.Code)
+	}
+
+	var dashboard models.PopulatedDashboard
+	if err := json.NewDecoder(rec.Body).Decode(&dashboard); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	f := dashboard.Features
+	if len(f.Languages) != 2 || len(f.Borders) != 3 || f.Region != "Europe" || f.Subregion != "Northern Europe" {
+		t.Errorf("Unexpected languages, borders or region: %+v", f)
+	}
+	if f.Flag == nil || f.Flag.Emoji != "🇳🇴" || f.Flag.PNG == "" {
+		t.Errorf("Expected flag emoji and URL, got %+v", f.Flag)
+	}
+	if len(f.CallingCodes) != 1 || f.CallingCodes[0] != "+47" {
+		t.Errorf("Expected calling code +47, got %v", f.CallingCodes)
+	}
+	if f.DrivingSide != "right" || len(f.TopLevelDomains) != 1 || len(f.Timezones) != 1 {
+		t.Errorf("Unexpected driving side, TLD or timezones: %+v", f)
+	}
+	if f.Temperature != 0 || f.Capital != "" {
+		t.Errorf("Expected disabled features to be left out, got %+v", f)
+	}
+}
+
+// Test the computed features derived from the fetched country data
+func TestGetPopulatedDashboard_ComputedMetrics(t *testing.T) {
+	mockCountries := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := `[
+			{
+				"name": { "common": "Norway" },
+				"cca2": "NO",
+				"latlng": [62.0, 10.0],
+				"population": 5000000,
+				"area": 250000,
+				"timezones": ["UTC+01:00"],
+				"capitalInfo": { "latlng": [59.92, 10.75] }
+			}
+		]`
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(resp))
+	}))
+	defer mockCountries.Close()
+	useMockAPI(t, &constants.RestCountriesAPI, mockCountries.URL)
+
+	reg := models.Registration{
+		Country: "Norway",
+		IsoCode: "NO",
+		Features: models.Features{
+			PopulationDensity: true,
+			LocalTime:         true,
+			Daylight:          true,
+		},
+	}
+	docRef, _, err := firestore.Client.Collection("registrations").Add(context.Background(), reg)
+	if err != nil {
+		t.Fatalf("Failed to insert test registration: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/dashboard/v1/dashboards/"+docRef.ID, nil)
+	rec := httptest.NewRecorder()
+
+	GetPopulatedDashboard(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", rec.Code)
+	}
+
+	var dashboard models.PopulatedDashboard
+	if err := json.NewDecoder(rec.Body).Decode(&dashboard); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	f := dashboard.Features
+	if f.PopulationDensity != 20 {
+		t.Errorf("Expected population density 20, got %v", f.PopulationDensity)
+	}
+	// The capital's zone follows daylight saving time, unlike the UTC+01:00 of REST Countries
+	oslo, _ := time.LoadLocation("Europe/Oslo")
+	offset := time.Now().In(oslo).Format("-07:00")
+	if f.LocalTime == nil || f.LocalTime.Timezone != "Europe/Oslo" || !strings.HasSuffix(f.LocalTime.Time, offset) {
+		t.Errorf("Expected local time in Europe/Oslo, got %+v", f.LocalTime)
+	}
+	if f.Daylight == nil || f.Daylight.DayLength == "" {
+		t.Errorf("Expected daylight information, got %+v", f.Daylight)
+	}
+}
+
+// Test that neighbouring countries are populated when the registration asks for them
+func TestGetPopulatedDashboard_Neighbours(t *testing.T) {
+	mockCountries := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/SWE"):
+			w.Write([]byte(`[{"name": {"common": "Sweden"}, "cca2": "SE", "cca3": "SWE", "capital": ["Stockholm"], "borders": ["NOR", "FIN"]}]`))
+		case strings.HasSuffix(r.URL.Path, "/FIN"):
+			w.Write([]byte(`[{"name": {"common": "Finland"}, "cca2": "FI", "cca3": "FIN", "capital": ["Helsinki"], "borders": ["NOR", "SWE", "RUS"]}]`))
+		case strings.HasSuffix(r.URL.Path, "/RUS"):
+			http.Error(w, "Not Found", http.StatusNotFound)
+		default:
+			w.Write([]byte(`[{"name": {"common": "Norway"}, "cca2": "NO", "cca3": "NOR", "capital": ["Oslo"], "borders": ["FIN", "SWE", "RUS"]}]`))
+		}
+	}))
+	defer mockCountries.Close()
+	useMockAPI(t, &constants.RestCountriesAPI, mockCountries.URL)
+
+	reg := models.Registration{
+		Country:    "Norway",
+		IsoCode:    "NO",
+		Neighbours: true,
+		Features:   models.Features{Capital: true},
+	}
+	docRef, _, err := firestore.Client.Collection("registrations").Add(context.Background(), reg)
+	if err != nil {
+		t.Fatalf("Failed to insert test registration: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/dashboard/v1/dashboards/"+docRef.ID, nil)
+	rec := httptest.NewRecorder()
+
+	GetPopulatedDashboard(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", rec.Code)
+	}
+
+	var dashboard models.PopulatedDashboard
+	if err := json.NewDecoder(rec.Body).Decode(&dashboard); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// Russia can't be looked up and is skipped
+	if len(dashboard.Neighbours) != 2 {
+		t.Fatalf("Expected 2 neighbours, got %d", len(dashboard.Neighbours))
+	}
+	capitals := map[string]string{}
+	for _, n := range dashboard.Neighbours {
+		capitals[n.Country] = n.Features.Capital
+	}
+	if capitals["Finland"] != "Helsinki" || capitals["Sweden"] != "Stockholm" {
+		t.Errorf("Unexpected neighbour capitals: %v", capitals)
+	}
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/firestore"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"Country-Dashboard-Service/internal/utils"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// deliveryResponse shows a queued delivery with its payload as JSON rather than an escaped string.
+type deliveryResponse struct {
+	models.WebhookDelivery
+	Payload json.RawMessage `json:"payload"`
+}
+
+// deliveryAttemptResponse shows a logged attempt with its payload as JSON rather than an escaped string.
+type deliveryAttemptResponse struct {
+	models.DeliveryAttempt
+	Payload json.RawMessage `json:"payload"`
+}
+
+// deliveryAttemptPage is a page of the delivery log as returned by the API.
+type deliveryAttemptPage struct {
+	models.DeliveryAttemptPage
+	Attempts []deliveryAttemptResponse `json:"attempts"`
+}
+
+/*
+DeliveriesHandler lets operators inspect the webhook delivery queue and re-drive dead letters.
+GET /deliveries/ lists deliveries, filtered by ?status= and ?webhook=, and GET /deliveries/{id} returns one.
+POST /deliveries/{id}/redrive re-drives a dead-lettered delivery,
+and POST /deliveries/redrive re-drives all dead letters, optionally only those of ?webhook=.
+*/
+func DeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) < 5 || parts[4] == "" {
+		if r.Method != http.MethodGet {
+			http.Error(w, errorMessages.MethodNotAllowed, http.StatusMethodNotAllowed)
+			return
+		}
+		listDeliveries(w, r)
+		return
+	}
+
+	// Re-drive: POST /deliveries/redrive or POST /deliveries/{id}/redrive
+	if parts[4] == constants.RedriveSegment || (len(parts) > 5 && parts[5] == constants.RedriveSegment) {
+		if r.Method != http.MethodPost {
+			http.Error(w, errorMessages.MethodNotAllowed, http.StatusMethodNotAllowed)
+			return
+		}
+		if parts[4] == constants.RedriveSegment {
+			redriveDeadDeliveries(w, r)
+		} else {
+			redriveDelivery(w, parts[4])
+		}
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, errorMessages.MethodNotAllowed, http.StatusMethodNotAllowed)
+		return
+	}
+	getDelivery(w, parts[4])
+}
+
+// listDeliveries returns the queued deliveries, oldest first.
+func listDeliveries(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", constants.DeliveryPending, constants.DeliveryDelivered, constants.DeliveryDead:
+	default:
+		http.Error(w, errorMessages.InvalidDeliveryStatus, http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := firestore.ListDeliveries(status, r.URL.Query().Get("webhook"))
+	if err != nil {
+		http.Error(w, errorMessages.FirestoreError+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response := make([]deliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, toDeliveryResponse(delivery))
+	}
+	utils.Encode(w, http.StatusOK, response)
+}
+
+// getDelivery returns a single queued delivery.
+func getDelivery(w http.ResponseWriter, id string) {
+	delivery, err := firestore.GetDelivery(id)
+	if err != nil {
+		http.Error(w, errorMessages.DeliveryNotFound, http.StatusNotFound)
+		return
+	}
+	utils.Encode(w, http.StatusOK, toDeliveryResponse(*delivery))
+}
+
+// redriveDelivery puts a single dead-lettered delivery back in the queue.
+func redriveDelivery(w http.ResponseWriter, id string) {
+	delivery, err := services.RedriveDelivery(id)
+	switch {
+	case errors.Is(err, firestore.ErrDeliveryNotFound):
+		http.Error(w, errorMessages.DeliveryNotFound, http.StatusNotFound)
+		return
+	case errors.Is(err, services.ErrDeliveryNotDead):
+		http.Error(w, errorMessages.DeliveryNotDead, http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, errorMessages.FirestoreError+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.Encode(w, http.StatusAccepted, toDeliveryResponse(*delivery))
+}
+
+// redriveDeadDeliveries puts all dead-lettered deliveries, optionally of one webhook, back in the queue.
+func redriveDeadDeliveries(w http.ResponseWriter, r *http.Request) {
+	ids, err := services.RedriveDeadDeliveries(r.URL.Query().Get("webhook"))
+	if err != nil {
+		http.Error(w, errorMessages.FirestoreError+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response := map[string]interface{}{
+		"redriven": ids,
+		"count":    len(ids),
+	}
+	utils.Encode(w, http.StatusAccepted, response)
+}
+
+/*
+listDeliveryAttempts returns a page of a webhook's delivery log, newest first.
+The log can be filtered by ?event=, ?status=success or failed, and a ?since= and ?until= time range.
+*/
+func listDeliveryAttempts(w http.ResponseWriter, r *http.Request, webhookID string) {
+	query := r.URL.Query()
+	page, errPage := positiveIntParam(query.Get("page"), 1)
+	limit, errLimit := positiveIntParam(query.Get("limit"), constants.DefaultPageSize)
+	if errPage != nil || errLimit != nil {
+		http.Error(w, errorMessages.InvalidPagination, http.StatusBadRequest)
+		return
+	}
+	limit = min(limit, constants.MaxPageSize)
+
+	status := query.Get("status")
+	if status != "" && status != "success" && status != "failed" {
+		http.Error(w, errorMessages.InvalidDeliveryOutcome, http.StatusBadRequest)
+		return
+	}
+	since, errSince := timeParam(query.Get("since"))
+	until, errUntil := timeParam(query.Get("until"))
+	if errSince != nil || errUntil != nil {
+		http.Error(w, errorMessages.InvalidTimeRange, http.StatusBadRequest)
+		return
+	}
+
+	attempts, err := firestore.ListDeliveryAttempts(webhookID)
+	if err != nil {
+		http.Error(w, errorMessages.FirestoreError+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	event := query.Get("event")
+	filtered := make([]deliveryAttemptResponse, 0, len(attempts))
+	for _, attempt := range attempts {
+		if event != "" && !strings.EqualFold(attempt.Event, event) {
+			continue
+		}
+		if status != "" && attempt.Success != (status == "success") {
+			continue
+		}
+		if (!since.IsZero() && attempt.Time.Before(since)) || (!until.IsZero() && attempt.Time.After(until)) {
+			continue
+		}
+		filtered = append(filtered, deliveryAttemptResponse{DeliveryAttempt: attempt, Payload: rawPayload(attempt.Payload)})
+	}
+
+	start, end := pageBounds(len(filtered), page, limit)
+	utils.Encode(w, http.StatusOK, deliveryAttemptPage{
+		DeliveryAttemptPage: models.DeliveryAttemptPage{Total: len(filtered), Page: page, Limit: limit},
+		Attempts:            filtered[start:end],
+	})
+}
+
+// timeParam parses an optional RFC 3339 query parameter. An empty value gives the zero time.
+func timeParam(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+// toDeliveryResponse converts a stored delivery to its API representation.
+func toDeliveryResponse(delivery models.WebhookDelivery) deliveryResponse {
+	return deliveryResponse{WebhookDelivery: delivery, Payload: rawPayload(delivery.Payload)}
+}
+
+// rawPayload embeds a stored JSON payload as is, or as a string if it is not valid JSON.
+func rawPayload(payload string) json.RawMessage {
+	if json.Valid([]byte(payload)) {
+		return json.RawMessage(payload)
+	}
+	quoted, _ := json.Marshal(payload)
+	return quoted
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/firestore"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// startStatusReceiver starts a webhook receiver answering with the given status codes in turn, repeating the last one
+func startStatusReceiver(t *testing.T, codes ...int) *httptest.Server {
+	t.Helper()
+
+	var calls atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1)) - 1
+		w.WriteHeader(codes[min(n, len(codes)-1)])
+	}))
+}
+
+// awaitDelivery waits until the webhook's delivery has the given status and returns it
+func awaitDelivery(t *testing.T, webhookID, status string) models.WebhookDelivery {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		deliveries, err := firestore.ListDeliveries(status, webhookID)
+		if err == nil && len(deliveries) > 0 {
+			return deliveries[0]
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for a %s delivery", status)
+	return models.WebhookDelivery{}
+}
+
+func TestTriggerWebhookEvent_DeliversThroughQueue(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusOK)
+	defer receiver.Close()
+	id := insertSignedWebhook(t, receiver.URL, "secret")
+
+	services.TriggerWebhookEvent(constants.EventInvoke, "SE")
+
+	delivery := awaitDelivery(t, id, constants.DeliveryDelivered)
+	if delivery.Attempts != 1 || delivery.LastStatusCode != http.StatusOK {
+		t.Errorf("Expected one successful attempt, got %d attempts with status %d", delivery.Attempts, delivery.LastStatusCode)
+	}
+}
+
+func TestAttemptDelivery_RetriesServerErrors(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusServiceUnavailable, http.StatusOK)
+	defer receiver.Close()
+	id := insertSignedWebhook(t, receiver.URL, "secret")
+
+	services.TriggerWebhookEvent(constants.EventInvoke, "SE")
+
+	// The failed attempt is scheduled for a retry after a backoff
+	var delivery models.WebhookDelivery
+	deadline := time.Now().Add(2 * time.Second)
+	for delivery.Attempts == 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+		delivery = awaitDelivery(t, id, constants.DeliveryPending)
+	}
+	if delivery.Attempts != 1 || delivery.LastStatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Expected one failed attempt, got %d attempts with status %d", delivery.Attempts, delivery.LastStatusCode)
+	}
+	if !delivery.NextAttempt.After(time.Now()) {
+		t.Errorf("Expected the retry to be delayed, next attempt at %v", delivery.NextAttempt)
+	}
+
+	// Not due yet, so nothing happens
+	services.AttemptDelivery(delivery.ID)
+	if stored, _ := firestore.GetDelivery(delivery.ID); stored.Attempts != 1 {
+		t.Fatalf("Expected the retry to wait for its backoff, got %d attempts", stored.Attempts)
+	}
+
+	delivery.NextAttempt = time.Now()
+	if err := firestore.SaveDelivery(&delivery); err != nil {
+		t.Fatalf("Failed to reschedule delivery: %v", err)
+	}
+	services.AttemptDelivery(delivery.ID)
+
+	delivery = awaitDelivery(t, id, constants.DeliveryDelivered)
+	if delivery.Attempts != 2 {
+		t.Errorf("Expected delivery on the second attempt, got %d attempts", delivery.Attempts)
+	}
+}
+
+func TestAttemptDelivery_CapsRetryAfter(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "99999999")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer receiver.Close()
+	id := insertSignedWebhook(t, receiver.URL, "secret")
+
+	services.TriggerWebhookEvent(constants.EventInvoke, "SE")
+
+	var delivery models.WebhookDelivery
+	deadline := time.Now().Add(2 * time.Second)
+	for delivery.Attempts == 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+		delivery = awaitDelivery(t, id, constants.DeliveryPending)
+	}
+	if delivery.NextAttempt.After(time.Now().Add(constants.DeliveryBackoffMax)) {
+		t.Errorf("Expected the Retry-After to be capped at %v, next attempt at %v", constants.DeliveryBackoffMax, delivery.NextAttempt)
+	}
+}
+
+func TestAttemptDelivery_DeadLettersClientErrors(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusGone)
+	defer receiver.Close()
+	id := insertSignedWebhook(t, receiver.URL, "secret")
+
+	services.TriggerWebhookEvent(constants.EventInvoke, "SE")
+
+	delivery := awaitDelivery(t, id, constants.DeliveryDead)
+	if delivery.Attempts != 1 || delivery.LastError == "" {
+		t.Errorf("Expected a single failed attempt with an error, got %+v", delivery)
+	}
+}
+
+func TestDeliveriesHandler_ListsDeadLetters(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusBadRequest)
+	defer receiver.Close()
+	id := insertSignedWebhook(t, receiver.URL, "secret")
+
+	services.TriggerWebhookEvent(constants.EventInvoke, "SE")
+	awaitDelivery(t, id, constants.DeliveryDead)
+
+	req := httptest.NewRequest(http.MethodGet, constants.Deliveries+"?status=dead&webhook="+id, nil)
+	w := httptest.NewRecorder()
+	DeliveriesHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 OK, got %d", w.Code)
+	}
+	var deliveries []map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&deliveries); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("Expected one dead letter, got %d", len(deliveries))
+	}
+	// The payload is embedded as JSON, not as a string
+	if payload, ok := deliveries[0]["payload"].(map[string]interface{}); !ok || payload["event"] != constants.EventInvoke {
+		t.Errorf("Expected the payload object, got %v", deliveries[0]["payload"])
+	}
+}
+
+func TestDeliveriesHandler_InvalidStatus(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, constants.Deliveries+"?status=lost", nil)
+	w := httptest.NewRecorder()
+
+	DeliveriesHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 Bad Request, got %d", w.Code)
+	}
+}
+
+func TestDeliveriesHandler_Redrive(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusNotFound, http.StatusOK)
+	defer receiver.Close()
+	id := insertSignedWebhook(t, receiver.URL, "secret")
+
+	services.TriggerWebhookEvent(constants.EventInvoke, "SE")
+	dead := awaitDelivery(t, id, constants.DeliveryDead)
+
+	req := httptest.NewRequest(http.MethodPost, constants.Deliveries+dead.ID+"/redrive", nil)
+	w := httptest.NewRecorder()
+	DeliveriesHandler(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected status 202 Accepted, got %d", w.Code)
+	}
+	delivered := awaitDelivery(t, id, constants.DeliveryDelivered)
+	if delivered.ID != dead.ID || delivered.Attempts != 1 {
+		t.Errorf("Expected the re-driven delivery to succeed on a fresh attempt, got %+v", delivered)
+	}
+
+	// Only dead letters can be re-driven
+	w = httptest.NewRecorder()
+	DeliveriesHandler(w, httptest.NewRequest(http.MethodPost, constants.Deliveries+dead.ID+"/redrive", nil))
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 Conflict, got %d", w.Code)
+	}
+}
+
+func TestDeliveriesHandler_RedriveAll(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusBadRequest, http.StatusOK)
+	defer receiver.Close()
+	id := insertSignedWebhook(t, receiver.URL, "secret")
+
+	services.TriggerWebhookEvent(constants.EventInvoke, "SE")
+	awaitDelivery(t, id, constants.DeliveryDead)
+
+	req := httptest.NewRequest(http.MethodPost, constants.Deliveries+"redrive?webhook="+id, nil)
+	w := httptest.NewRecorder()
+	DeliveriesHandler(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected status 202 Accepted, got %d", w.Code)
+	}
+	awaitDelivery(t, id, constants.DeliveryDelivered)
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/firestore"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// getDeliveryLog fetches a page of the webhook's delivery log with the given query
+func getDeliveryLog(t *testing.T, webhookID, query string) (int, deliveryAttemptPage) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, constants.Notifications+webhookID+"/deliveries"+query, nil)
+	w := httptest.NewRecorder()
+	NotificationsHandler(w, req)
+
+	var page deliveryAttemptPage
+	if w.Code == http.StatusOK {
+		if err := json.NewDecoder(w.Body).Decode(&page); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	}
+	return w.Code, page
+}
+
+func TestNotificationDeliveries_RecordsAttempts(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusBadGateway, http.StatusOK)
+	defer receiver.Close()
+	id := insertSignedWebhook(t, receiver.URL, "secret")
+
+	services.TriggerWebhookEvent(constants.EventInvoke, "SE")
+	var delivery models.WebhookDelivery
+	deadline := time.Now().Add(2 * time.Second)
+	for delivery.Attempts == 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+		delivery = awaitDelivery(t, id, constants.DeliveryPending)
+	}
+	delivery.NextAttempt = time.Now()
+	if err := firestore.SaveDelivery(&delivery); err != nil {
+		t.Fatalf("Failed to reschedule delivery: %v", err)
+	}
+	services.AttemptDelivery(delivery.ID)
+
+	code, page := getDeliveryLog(t, id, "")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200 OK, got %d", code)
+	}
+	if page.Total != 2 || len(page.Attempts) != 2 {
+		t.Fatalf("Expected two logged attempts, got %d", page.Total)
+	}
+	latest := page.Attempts[0]
+	if latest.Attempt != 2 || !latest.Success || latest.StatusCode != http.StatusOK || latest.Event != constants.EventInvoke {
+		t.Errorf("Expected the successful second attempt first, got %+v", latest)
+	}
+
+	// Filtering on the outcome
+	_, page = getDeliveryLog(t, id, "?status=failed")
+	if page.Total != 1 || page.Attempts[0].StatusCode != http.StatusBadGateway || page.Attempts[0].Error == "" {
+		t.Errorf("Expected the failed first attempt, got %+v", page.Attempts)
+	}
+
+	// Pagination
+	_, page = getDeliveryLog(t, id, "?limit=1&page=2")
+	if page.Total != 2 || len(page.Attempts) != 1 || page.Attempts[0].Attempt != 1 {
+		t.Errorf("Expected the first attempt on page two, got %+v", page.Attempts)
+	}
+	code, page = getDeliveryLog(t, id, "?limit=100&page=184467440737095517")
+	if code != http.StatusOK || page.Total != 2 || len(page.Attempts) != 0 {
+		t.Errorf("Expected an empty page past the last one, got %d %+v", code, page.Attempts)
+	}
+
+	// Time range
+	_, page = getDeliveryLog(t, id, "?since="+time.Now().Add(time.Hour).Format(time.RFC3339))
+	if page.Total != 0 {
+		t.Errorf("Expected no attempts in the future, got %d", page.Total)
+	}
+}
+
+func TestNotificationDeliveries_InvalidFilters(t *testing.T) {
+	for _, query := range []string{"?status=lost", "?since=yesterday", "?page=0"} {
+		if code, _ := getDeliveryLog(t, "some-id", query); code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 Bad Request for %s, got %d", query, code)
+		}
+	}
+}
+
+func TestPruneDeliveryAttempts(t *testing.T) {
+	old := models.DeliveryAttempt{WebhookID: "prune-test", Attempt: 1, Time: time.Now().Add(-60 * 24 * time.Hour)}
+	recent := models.DeliveryAttempt{WebhookID: "prune-test", Attempt: 2, Time: time.Now()}
+	for _, attempt := range []*models.DeliveryAttempt{&old, &recent} {
+		if err := firestore.AddDeliveryAttempt(attempt); err != nil {
+			t.Fatalf("Failed to log attempt: %v", err)
+		}
+	}
+
+	if _, err := firestore.PruneDeliveryAttempts(time.Now().Add(-services.DeliveryLogRetention())); err != nil {
+		t.Fatalf("Failed to prune: %v", err)
+	}
+
+	attempts, err := firestore.ListDeliveryAttempts("prune-test")
+	if err != nil {
+		t.Fatalf("Failed to list attempts: %v", err)
+	}
+	if len(attempts) != 1 || attempts[0].ID != recent.ID {
+		t.Errorf("Expected only the recent attempt to remain, got %+v", attempts)
+	}
+}
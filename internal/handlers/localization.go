@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"Country-Dashboard-Service/internal/utils"
+	"net/http"
+	"strings"
+)
+
+// Localized wraps a handler so its error messages are returned in the language of the Accept-Language header.
+// Languages without a message catalogue fall back to English.
+func Localized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		language := errorMessages.MessageLanguage(utils.PreferredLanguages(r))
+		if language == errorMessages.LanguageEnglish {
+			next(w, r)
+			return
+		}
+		next(&localizedWriter{ResponseWriter: w, language: language}, r)
+	}
+}
+
+// localizedWriter translates plain-text error responses, as written by http.Error.
+type localizedWriter struct {
+	http.ResponseWriter
+	language  string
+	translate bool
+}
+
+// WriteHeader marks plain-text error responses for translation.
+func (lw *localizedWriter) WriteHeader(status int) {
+	if status >= http.StatusBadRequest && strings.HasPrefix(lw.Header().Get("Content-Type"), "text/plain") {
+		lw.translate = true
+		lw.Header().Set("Content-Language", lw.language)
+	}
+	lw.ResponseWriter.WriteHeader(status)
+}
+
+// Write translates the body of error responses and passes everything else through.
+func (lw *localizedWriter) Write(b []byte) (int, error) {
+	if !lw.translate {
+		return lw.ResponseWriter.Write(b)
+	}
+	if _, err := lw.ResponseWriter.Write([]byte(errorMessages.Localize(string(b), lw.language))); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// localizeDashboard replaces the country names of a dashboard, its neighbours and members with their translations.
+func localizeDashboard(dashboard *models.PopulatedDashboard, languages []string) {
+	dashboard.Country = services.LocalizedCountryName(dashboard.Country, languages)
+	for i := range dashboard.Neighbours {
+		localizeDashboard(&dashboard.Neighbours[i], languages)
+	}
+	for i := range dashboard.Members {
+		localizeDashboard(&dashboard.Members[i], languages)
+	}
+}
+
+// localizeRegistration adds the translations of a registration's country names.
+// The country fields keep their canonical names, so a registration read and sent back with PUT is stored unchanged.
+func localizeRegistration(reg *models.Registration, languages []string) {
+	if name := services.LocalizedCountryName(reg.Country, languages); name != reg.Country {
+		reg.CountryName = name
+	}
+	translated := false
+	names := make([]string, len(reg.Countries))
+	for i, country := range reg.Countries {
+		names[i] = services.LocalizedCountryName(country, languages)
+		translated = translated || names[i] != country
+	}
+	if translated {
+		reg.CountryNames = names
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Setup mocked REST Countries catalogue with translated country names
+func startMockTranslatedCatalogueAPI(t *testing.T) func() {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"name": {"common": "Norway"}, "cca2": "NO",
+				"translations": {"deu": {"common": "Norwegen"}, "swe": {"common": "Norge"}}}
+		]`))
+	}))
+	useMockAPI(t, &constants.RestCountriesAPI, mock.URL)
+	if _, err := services.RefreshCountryCatalogue(); err != nil {
+		t.Fatalf("Failed to load mock catalogue: %v", err)
+	}
+	return mock.Close
+}
+
+func TestLocalizedErrors_Norwegian(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPatch, constants.Registrations, nil)
+	req.Header.Set("Accept-Language", "nb-NO,nb;q=0.9,en;q=0.8")
+	w := httptest.NewRecorder()
+
+	Localized(RegistrationsHandler)(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405 Method Not Allowed, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "metoden er ikke tillatt") {
+		t.Errorf("expected Norwegian error message, got %q", w.Body.String())
+	}
+	if w.Header().Get("Content-Language") != "nb" {
+		t.Errorf("expected Content-Language nb, got %q", w.Header().Get("Content-Language"))
+	}
+}
+
+func TestLocalizedErrors_FallsBackToEnglish(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPatch, constants.Registrations, nil)
+	req.Header.Set("Accept-Language", "fr-FR,fr;q=0.9")
+	w := httptest.NewRecorder()
+
+	Localized(RegistrationsHandler)(w, req)
+
+	if !strings.Contains(w.Body.String(), "attempted method not allowed") {
+		t.Errorf("expected English error message, got %q", w.Body.String())
+	}
+}
+
+func TestGetSpecificRegistration_LocalizedCountry(t *testing.T) {
+	closeMock := startMockTranslatedCatalogueAPI(t)
+	defer closeMock()
+
+	id := insertCountryRegistration(t, "Norway", models.Features{Capital: true})
+
+	req := httptest.NewRequest(http.MethodGet, constants.Registrations+id, nil)
+	req.Header.Set("Accept-Language", "nb, de;q=0.8")
+	w := httptest.NewRecorder()
+
+	RegistrationsHandler(w, req)
+
+	var reg models.Registration
+	if err := json.NewDecoder(w.Body).Decode(&reg); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	// REST Countries has no Norwegian translations, so the German name is the best match
+	if reg.CountryName != "Norwegen" {
+		t.Errorf("expected country name Norwegen, got %q", reg.CountryName)
+	}
+	if reg.Country != "Norway" {
+		t.Errorf("expected the canonical country to be kept, got %q", reg.Country)
+	}
+}
+
+func TestLocalize_OnlyLeadingMessage(t *testing.T) {
+	// Upstream error text in the details is kept, even where it happens to contain a catalogue message
+	msg := errorMessages.FirestoreError + errorMessages.MethodNotAllowed
+	if got := errorMessages.Localize(msg, errorMessages.LanguageNorwegian); got != "kunne ikke lagre til Firestore: "+errorMessages.MethodNotAllowed {
+		t.Errorf("expected only the leading message to be translated, got %q", got)
+	}
+
+	msg = errorMessages.APINotFound + ": Netherlnds (" + errorMessages.DidYouMean + ": Netherlands?)"
+	if got := errorMessages.Localize(msg, errorMessages.LanguageNorwegian); !strings.Contains(got, "(mente du: Netherlands?)") {
+		t.Errorf("expected the suggestion to be translated, got %q", got)
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"Country-Dashboard-Service/constants"
 	"Country-Dashboard-Service/internal/firestore"
 	"context"
 	"os"
@@ -9,7 +10,7 @@ import (
 
 // cleanupFirestore deletes all documents from the test collections
 func cleanupFirestore(t *testing.T) {
-	collections := []string{"registrations", "notifications"}
+	collections := []string{"registrations", "notifications", "deliveries", "delivery_log", "invocations"}
 
 	for _, col := range collections {
 		iter := firestore.Client.Collection(col).Documents(context.Background())
@@ -30,6 +31,9 @@ func TestMain(m *testing.M) {
 	// Set environment variable for test mode
 	os.Setenv("GO_ENV", "test")
 
+	// Mock webhook receivers run on the loopback interface, which deliveries may not reach by default
+	os.Setenv(constants.EnvWebhookAllowedNetworks, "127.0.0.1,::1")
+
 	// Initialize Firestore emulator
 	firestore.InitFirestore()
 
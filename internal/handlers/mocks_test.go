@@ -0,0 +1,13 @@
+package handlers
+
+import "testing"
+
+// useMockAPI points the given API URL at a mock server for the rest of the test
+// and restores the previous URL when the test finishes.
+func useMockAPI(t *testing.T, api *string, url string) {
+	t.Helper()
+
+	old := *api
+	*api = url
+	t.Cleanup(func() { *api = old })
+}
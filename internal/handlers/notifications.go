@@ -1,14 +1,23 @@
 package handlers
 
 import (
+	"Country-Dashboard-Service/constants"
 	"Country-Dashboard-Service/constants/errorMessages"
 	"Country-Dashboard-Service/internal/firestore"
 	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
 	"Country-Dashboard-Service/internal/utils"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
+	"time"
 )
 
 /*
@@ -19,6 +28,33 @@ func NotificationsHandler(w http.ResponseWriter, r *http.Request) {
 	parts := strings.Split(r.URL.Path, "/")
 	// If an ID is provided, handle GET or DELETE for a specific webhook.
 	if len(parts) > 4 && parts[4] != "" {
+		// Secret rotation: POST /notifications/{id}/rotate-secret
+		if len(parts) > 5 && parts[5] == constants.RotateSecretSegment {
+			if r.Method != http.MethodPost {
+				http.Error(w, errorMessages.MethodNotAllowed, http.StatusMethodNotAllowed)
+				return
+			}
+			rotateNotificationSecret(w, r, parts[4])
+			return
+		}
+		// Test delivery: POST /notifications/{id}/test
+		if len(parts) > 5 && parts[5] == constants.TestSegment {
+			if r.Method != http.MethodPost {
+				http.Error(w, errorMessages.MethodNotAllowed, http.StatusMethodNotAllowed)
+				return
+			}
+			testNotification(w, parts[4])
+			return
+		}
+		// Delivery log: GET /notifications/{id}/deliveries
+		if len(parts) > 5 && parts[5] == constants.DeliveriesSegment {
+			if r.Method != http.MethodGet {
+				http.Error(w, errorMessages.MethodNotAllowed, http.StatusMethodNotAllowed)
+				return
+			}
+			listDeliveryAttempts(w, r, parts[4])
+			return
+		}
 		switch r.Method {
 		case http.MethodGet:
 			getSpecificNotification(w, parts[4])
@@ -46,6 +82,19 @@ func postNotification(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, errorMessages.InvalidJSON, http.StatusBadRequest)
 		return
 	}
+	if err := validateWebhook(&webhook); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Deliveries are signed with the secret, which is generated if the client did not choose one
+	if webhook.Secret == "" {
+		secret, err := services.GenerateWebhookSecret()
+		if err != nil {
+			http.Error(w, errorMessages.SecretGenerationError+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		webhook.Secret = secret
+	}
 	docRef, _, err := firestore.Client.Collection("notifications").Add(context.Background(), webhook)
 	if err != nil {
 		http.Error(w, errorMessages.FirestoreError+err.Error(), http.StatusInternalServerError)
@@ -57,12 +106,162 @@ func postNotification(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, errorMessages.FirestoreError+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	// The secret is only shown here and when it is rotated
 	response := map[string]interface{}{
-		"id": webhook.ID,
+		"id":     webhook.ID,
+		"secret": webhook.Secret,
 	}
 	utils.Encode(w, http.StatusCreated, response)
 }
 
+// validateWebhook checks the events and the URL of a new webhook registration and normalizes its filters.
+// Whether the URL points at an allowed address is checked at delivery time, when the host is resolved.
+func validateWebhook(webhook *models.WebhookRegistration) error {
+	// The single event and country fields are merged into the lists, and kept only if there is exactly one
+	events := make([]string, 0, len(webhook.Events)+1)
+	for _, event := range append([]string{webhook.Event}, webhook.Events...) {
+		event = strings.ToUpper(strings.TrimSpace(event))
+		switch event {
+		case "":
+			continue
+		case constants.EventRegister, constants.EventChange, constants.EventDelete, constants.EventInvoke,
+			constants.EventWeatherAlert, constants.EventRateChange, constants.EventWildcard:
+		default:
+			return errors.New(errorMessages.InvalidWebhookEvent)
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		return errors.New(errorMessages.InvalidWebhookEvent)
+	}
+	// The wildcard covers the registration events; alerts are subscribed to explicitly
+	if slices.Contains(events, constants.EventWildcard) {
+		events = slices.DeleteFunc(events, func(event string) bool {
+			return event != constants.EventWeatherAlert && event != constants.EventRateChange
+		})
+		events = append([]string{constants.EventWildcard}, events...)
+	}
+	webhook.Events = events
+	webhook.Event = ""
+	if len(events) == 1 && events[0] != constants.EventWildcard {
+		webhook.Event = events[0]
+	}
+
+	countries := make([]string, 0, len(webhook.Countries)+1)
+	for _, country := range append([]string{webhook.Country}, webhook.Countries...) {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if country != "" && !slices.Contains(countries, country) {
+			countries = append(countries, country)
+		}
+	}
+	webhook.Countries = nil
+	webhook.Country = ""
+	if len(countries) == 1 {
+		webhook.Country = countries[0]
+	} else if len(countries) > 1 {
+		webhook.Countries = countries
+	}
+
+	if err := validateWeatherCondition(webhook); err != nil {
+		return err
+	}
+	if err := validateRateCondition(webhook); err != nil {
+		return err
+	}
+	if webhook.InvokeEvery < 0 || (webhook.InvokeEvery > 0 && !slices.Contains(webhook.Events, constants.EventInvoke) &&
+		!slices.Contains(webhook.Events, constants.EventWildcard)) {
+		return errors.New(errorMessages.InvalidInvokeEvery)
+	}
+
+	webhook.URL = strings.TrimSpace(webhook.URL)
+	target, err := url.Parse(webhook.URL)
+	if err != nil || !target.IsAbs() || target.Hostname() == "" || (target.Scheme != "http" && target.Scheme != "https") {
+		return errors.New(errorMessages.InvalidWebhookURL)
+	}
+	return nil
+}
+
+// validateWeatherCondition checks that WEATHER_ALERT subscriptions, and only those, have a valid condition for one country.
+func validateWeatherCondition(webhook *models.WebhookRegistration) error {
+	webhook.AlertActive = false
+	if !slices.Contains(webhook.Events, constants.EventWeatherAlert) {
+		if webhook.Condition != nil {
+			return errors.New(errorMessages.InvalidWeatherCondition)
+		}
+		return nil
+	}
+
+	condition := webhook.Condition
+	if condition == nil || webhook.Country == "" {
+		return errors.New(errorMessages.InvalidWeatherCondition)
+	}
+	condition.Metric = strings.ToLower(strings.TrimSpace(condition.Metric))
+	condition.Operator = strings.ToLower(strings.TrimSpace(condition.Operator))
+	if condition.Metric != "temperature" && condition.Metric != "precipitation" {
+		return errors.New(errorMessages.InvalidWeatherCondition)
+	}
+	if condition.Operator != constants.ConditionBelow && condition.Operator != constants.ConditionAbove {
+		return errors.New(errorMessages.InvalidWeatherCondition)
+	}
+
+	// The weather is looked up by the country's ISO code on every evaluation, so names and aliases are resolved here
+	code, err := resolveAlertCountry(webhook.Country)
+	if err != nil {
+		return err
+	}
+	webhook.Country = code
+	return nil
+}
+
+// resolveAlertCountry returns the cca2 code of a country given by ISO code, name or alias.
+func resolveAlertCountry(country string) (string, error) {
+	if services.IsCountryCode(country) {
+		if _, code, err := resolveCountry("", country); err == nil {
+			return code, nil
+		}
+	}
+	_, code, err := resolveCountry(country, "")
+	return code, err
+}
+
+// validateRateCondition checks that RATE_CHANGE subscriptions, and only those, have a valid rate condition.
+func validateRateCondition(webhook *models.WebhookRegistration) error {
+	webhook.LastRateAlert = time.Time{}
+	if !slices.Contains(webhook.Events, constants.EventRateChange) {
+		if webhook.RateCondition != nil {
+			return errors.New(errorMessages.InvalidRateCondition)
+		}
+		return nil
+	}
+
+	condition := webhook.RateCondition
+	if condition == nil || condition.Threshold <= 0 {
+		return errors.New(errorMessages.InvalidRateCondition)
+	}
+	condition.Base = strings.ToUpper(strings.TrimSpace(condition.Base))
+	condition.Target = strings.ToUpper(strings.TrimSpace(condition.Target))
+	if len(condition.Base) != 3 || len(condition.Target) != 3 || condition.Base == condition.Target {
+		return errors.New(errorMessages.InvalidRateCondition)
+	}
+	window, err := time.ParseDuration(condition.Window)
+	if err != nil || window < constants.RateMonitorInterval || window > constants.MaxRateWindow {
+		return errors.New(errorMessages.InvalidRateCondition)
+	}
+
+	// The currencies are checked against the catalogue when it is available
+	unsupported, err := services.UnsupportedCurrencies([]string{condition.Base, condition.Target})
+	if err != nil {
+		log.Printf("Skipping currency validation: %v", err)
+		return nil
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("%s: %s", errorMessages.UnsupportedCurrencies, strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
 func getSpecificNotification(w http.ResponseWriter, id string) {
 	doc, err := firestore.Client.Collection("notifications").Doc(id).Get(context.Background())
 	if err != nil {
@@ -74,6 +273,7 @@ func getSpecificNotification(w http.ResponseWriter, id string) {
 		http.Error(w, errorMessages.DeserializationError+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	webhook.Secret = ""
 	utils.Encode(w, http.StatusOK, webhook)
 }
 
@@ -89,11 +289,76 @@ func getAllNotifications(w http.ResponseWriter, r *http.Request) {
 		if err := doc.DataTo(&webhook); err != nil {
 			continue
 		}
+		webhook.Secret = ""
 		webhooks = append(webhooks, webhook)
 	}
 	utils.Encode(w, http.StatusOK, webhooks)
 }
 
+// rotateNotificationSecret replaces the signing secret of a webhook and returns the new one.
+// The old secret keeps signing deliveries during the grace period, 24 hours unless the body says otherwise.
+func rotateNotificationSecret(w http.ResponseWriter, r *http.Request, id string) {
+	var rotation models.SecretRotation
+	if err := json.NewDecoder(r.Body).Decode(&rotation); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, errorMessages.InvalidJSON, http.StatusBadRequest)
+		return
+	}
+	grace := constants.DefaultSecretGracePeriod
+	if rotation.GracePeriod != "" {
+		parsed, err := time.ParseDuration(rotation.GracePeriod)
+		if err != nil || parsed < 0 || parsed > constants.MaxSecretGracePeriod {
+			http.Error(w, errorMessages.InvalidGracePeriod, http.StatusBadRequest)
+			return
+		}
+		grace = parsed
+	}
+
+	docRef := firestore.Client.Collection("notifications").Doc(id)
+	doc, err := docRef.Get(context.Background())
+	if err != nil {
+		http.Error(w, errorMessages.NotificationNotFound, http.StatusNotFound)
+		return
+	}
+	var webhook models.WebhookRegistration
+	if err := doc.DataTo(&webhook); err != nil {
+		http.Error(w, errorMessages.DeserializationError+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := services.RotateWebhookSecret(&webhook, rotation.Secret, grace); err != nil {
+		http.Error(w, errorMessages.SecretGenerationError+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, err := docRef.Set(context.Background(), webhook); err != nil {
+		http.Error(w, errorMessages.FirestoreError+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"id":     id,
+		"secret": webhook.Secret,
+	}
+	// The secret just replaced is the last one retired
+	if retired := len(webhook.PreviousSecrets); retired > 0 {
+		response["previousSecretExpires"] = webhook.PreviousSecrets[retired-1].Expiry.Format(time.RFC3339)
+	}
+	utils.Encode(w, http.StatusOK, response)
+}
+
+// testNotification sends a synthetic event to a webhook and returns how the receiver responded.
+func testNotification(w http.ResponseWriter, id string) {
+	result, err := services.TestWebhook(id)
+	if errors.Is(err, services.ErrWebhookNotFound) {
+		http.Error(w, errorMessages.NotificationNotFound, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, errorMessages.FirestoreError+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	utils.Encode(w, http.StatusOK, result)
+}
+
 // deleteNotificationHandler deletes a specific webhook registration.
 func deleteNotificationHandler(w http.ResponseWriter, id string) {
 	docRef := firestore.Client.Collection("notifications").Doc(id)
@@ -121,7 +386,7 @@ func deleteNotificationHandler(w http.ResponseWriter, id string) {
 
 // ClientReceiver can be used to simulate receiving webhook invocations during development.
 func ClientReceiver(w http.ResponseWriter, r *http.Request) {
-	var payload map[string]string
+	var payload models.WebhookPayload
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		http.Error(w, "Invalid JSON", http.StatusBadRequest)
 		return
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// startMockRateAPI sets up a currency API answering with the stored NOK to EUR rate
+func startMockRateAPI(t *testing.T, rate *atomic.Value) func() {
+	t.Helper()
+
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"base": "NOK", "rates": {"EUR": ` + rate.Load().(string) + `, "USD": 0.094}}`))
+	}))
+	useMockAPI(t, &constants.CurrencyAPI, mock.URL+"/")
+	return mock.Close
+}
+
+func TestRateChange_FiresOncePerMove(t *testing.T) {
+	closeCatalogue := startMockCurrencyCatalogueAPI(t)
+	defer closeCatalogue()
+	var rate atomic.Value
+	rate.Store(`0.0900`)
+	closeRates := startMockRateAPI(t, &rate)
+	defer closeRates()
+	receiver, payloads := startPayloadReceiver(t)
+	defer receiver.Close()
+	id := postSubscription(t, models.WebhookRegistration{
+		URL:           receiver.URL,
+		Event:         constants.EventRateChange,
+		RateCondition: &models.RateCondition{Base: "nok", Target: "eur", Threshold: 1, Window: "1h"},
+	})
+
+	// The first rate is the baseline
+	services.MonitorExchangeRates()
+	if events := queuedEvents(t, id); len(events) != 0 {
+		t.Fatalf("Expected no event without a baseline, got %v", events)
+	}
+
+	rate.Store(`0.0910`)
+	services.MonitorExchangeRates()
+	payload := awaitPayload(t, payloads)
+	change := payload.RateChange
+	if change == nil || change.OldRate != 0.09 || change.NewRate != 0.091 || change.ChangePercent != 1.111 {
+		t.Fatalf("Expected a 1.111%% change from 0.09 to 0.091, got %+v", change)
+	}
+	if payload.Event != constants.EventRateChange || change.Base != "NOK" || change.Target != "EUR" {
+		t.Errorf("Expected a RATE_CHANGE event for NOK/EUR, got %+v", payload)
+	}
+
+	// The move has been notified, so the rate staying there is not notified again
+	services.MonitorExchangeRates()
+	if events := queuedEvents(t, id); len(events) != 1 {
+		t.Errorf("Expected a single event for the move, got %v", events)
+	}
+}
+
+func TestRateChange_Validation(t *testing.T) {
+	closeCatalogue := startMockCurrencyCatalogueAPI(t)
+	defer closeCatalogue()
+
+	invalid := []*models.RateCondition{
+		nil,
+		{Base: "NOK", Target: "EUR", Threshold: 0, Window: "1h"},
+		{Base: "NOK", Target: "EUR", Threshold: 1, Window: "1m"},
+		{Base: "NOK", Target: "NOK", Threshold: 1, Window: "1h"},
+		{Base: "NOK", Target: "XYZ", Threshold: 1, Window: "1h"},
+	}
+	for _, condition := range invalid {
+		body, _ := json.Marshal(models.WebhookRegistration{URL: "https://example.com/hook", Event: constants.EventRateChange, RateCondition: condition})
+		w := httptest.NewRecorder()
+		NotificationsHandler(w, httptest.NewRequest(http.MethodPost, constants.Notifications, bytes.NewReader(body)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 Bad Request for %+v, got %d", condition, w.Code)
+		}
+	}
+}
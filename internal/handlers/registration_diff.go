@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/internal/models"
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// diffRegistrations returns the fields that differ between two versions of a registration, sorted by field.
+// Nested objects are compared field by field, lists as a whole. The last change time is left out.
+func diffRegistrations(before, after *models.Registration) []models.FieldChange {
+	beforeFields, afterFields := registrationFields(before), registrationFields(after)
+	delete(beforeFields, "lastChange")
+	delete(afterFields, "lastChange")
+
+	var changes []models.FieldChange
+	for field, value := range beforeFields {
+		if other, ok := afterFields[field]; !ok || !reflect.DeepEqual(value, other) {
+			changes = append(changes, models.FieldChange{Field: field, Before: value, After: afterFields[field]})
+		}
+	}
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes = append(changes, models.FieldChange{Field: field, After: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+// registrationFields flattens a registration's JSON representation to its fields, keyed by their dotted path.
+func registrationFields(registration *models.Registration) map[string]interface{} {
+	fields := make(map[string]interface{})
+	data, err := json.Marshal(registration)
+	if err != nil {
+		return fields
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fields
+	}
+	flattenFields("", doc, fields)
+	return fields
+}
+
+// flattenFields adds the values of a decoded JSON object to fields, descending into nested objects.
+func flattenFields(prefix string, doc map[string]interface{}, fields map[string]interface{}) {
+	for key, value := range doc {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenFields(key, nested, fields)
+			continue
+		}
+		fields[key] = value
+	}
+}
@@ -9,8 +9,11 @@ import (
 	"Country-Dashboard-Service/internal/utils"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 )
@@ -60,8 +63,13 @@ func postRegistrationsHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Validate the registration's name and ISO code.
-		if err := validateRegistration(registration); err != nil {
+		// Validate the registration's name and ISO code, and normalize the ISO code to cca2.
+		if err := validateRegistration(&registration); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		settings, err := requestTimeSettings(r, &registration)
+		if err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
@@ -85,17 +93,14 @@ func postRegistrationsHandler(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, errorMessages.InvalidRegistrationID+err.Error(), http.StatusInternalServerError)
 			return
 		}
-		// Trigger webhook for the REGISTER event.
-		// The event type is "REGISTER" and we pass the ISO code from the registration.
-		services.TriggerWebhookEvent(constants.EventRegister, registration.IsoCode)
-
-		// After successful Firestore write, trigger webhook
-		services.TriggerWebhookEvent(constants.EventRegister, registration.IsoCode)
+		// After successful Firestore write, trigger webhook for the REGISTER event.
+		// Multi-country and region registrations trigger it for every member country.
+		triggerRegistrationEvent(constants.EventRegister, &registration, nil, nil)
 
 		// Return the ID and LastChange time in the response. Confirmation message in JSON for the client.
 		response := map[string]interface{}{
 			"id":         id,
-			"lastChange": registration.LastChange,
+			"lastChange": registration.LastChange.WithSettings(settings),
 		}
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(response)
@@ -111,7 +116,7 @@ func getRegistrationsHandler(w http.ResponseWriter, r *http.Request) {
 	// Check if an ID exists after "/dashboard/v1/registrations/"
 	if len(parts) > 4 && parts[4] != "" {
 		// If ID is provided, fetch the specific registration.
-		getSpecifiedRegistration(w, parts[4])
+		getSpecifiedRegistration(w, r, parts[4])
 		return
 	}
 
@@ -120,7 +125,7 @@ func getRegistrationsHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // GetSpecifiedRegistration fetches a specific registration from Firestore based on the given ID.
-func getSpecifiedRegistration(w http.ResponseWriter, id string) {
+func getSpecifiedRegistration(w http.ResponseWriter, r *http.Request, id string) {
 	// Fetch the document from Firestore using the provided ID.
 	doc, err := firestore.Client.Collection("registrations").Doc(id).Get(context.Background())
 	if err != nil {
@@ -139,6 +144,13 @@ func getSpecifiedRegistration(w http.ResponseWriter, id string) {
 	}
 
 	reg.ID = doc.Ref.ID
+	localizeRegistration(&reg, utils.PreferredLanguages(r))
+	settings, err := requestTimeSettings(r, &reg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	reg.LastChange = reg.LastChange.WithSettings(settings)
 
 	// Return the registration as a JSON response.
 	w.Header().Set("Content-Type", "application/json")
@@ -147,9 +159,19 @@ func getSpecifiedRegistration(w http.ResponseWriter, id string) {
 
 // GetAllRegistrations retrieves all registrations from Firestore.
 func getAllRegistrations(w http.ResponseWriter, r *http.Request) {
+	// Time settings from the query apply to every registration, otherwise each uses its own.
+	settings, err := requestTimeSettings(r, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	fromQuery := query.Get(constants.TimezoneParam) != "" || query.Get(constants.TimeFormatParam) != ""
+
 	// Fetch all documents in the "registrations" collection.
 	iter := firestore.Client.Collection("registrations").Documents(context.Background())
 	var all []models.Registration
+	languages := utils.PreferredLanguages(r)
 
 	// Loop through all the documents and map them to the Registration model.
 	for {
@@ -165,6 +187,12 @@ func getAllRegistrations(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 		reg.ID = doc.Ref.ID
+		localizeRegistration(&reg, languages)
+		if fromQuery {
+			reg.LastChange = reg.LastChange.WithSettings(settings)
+		} else if own, err := requestTimeSettings(r, &reg); err == nil {
+			reg.LastChange = reg.LastChange.WithSettings(own)
+		}
 		// Add valid registrations to the list.
 		all = append(all, reg)
 	}
@@ -191,13 +219,14 @@ func deleteRegistration(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Extract the ISO code before deletion (required for the webhook)
+		// Extract the registration before deletion (required for the webhook)
 		var reg models.Registration
 		err = docSnap.DataTo(&reg)
 		if err != nil {
 			http.Error(w, errorMessages.ExtractionError, http.StatusInternalServerError)
 			return
 		}
+		reg.ID = id
 
 		// Proceed with deletion
 		_, err = docRef.Delete(context.Background())
@@ -207,7 +236,7 @@ func deleteRegistration(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Trigger webhook
-		services.TriggerWebhookEvent(constants.EventDelete, reg.IsoCode)
+		triggerRegistrationEvent(constants.EventDelete, &reg, nil, nil)
 
 		// Return a success response
 		response := map[string]interface{}{
@@ -242,6 +271,10 @@ func putRegistration(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, errorMessages.ReadingError, http.StatusInternalServerError)
 			return
 		}
+		existing.ID = id
+		// Keep the stored version for the change diff in the webhook payload
+		before := existing
+		before.Features.TargetCurrencies = slices.Clone(existing.Features.TargetCurrencies)
 
 		// Decode request body into a map to allow partial updates
 		var incoming map[string]interface{}
@@ -250,36 +283,56 @@ func putRegistration(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Handle country and ISO code
-		if country, ok := incoming["country"].(string); ok && country != "" {
-			// Update country if provided
-			existing.Country = country
-			// If ISO code is also provided, validate it
-			if isoCode, ok := incoming["isoCode"].(string); ok && isoCode != "" {
-				if err := validateCountryISO(country, isoCode); err != nil {
-					http.Error(w, err.Error(), http.StatusBadRequest)
-					return
-				}
-				existing.IsoCode = isoCode // Update ISO code if provided
-			} else {
-				// If no ISO code provided, ensure it matches the current registration
-				if existing.IsoCode == "" || existing.Country != country {
-					http.Error(w, errorMessages.IsoCodeDoesNotMatch, http.StatusBadRequest)
-					return
-				}
+		// Handle country and ISO code. Either can be given alone, the missing one is resolved.
+		country, _ := incoming["country"].(string)
+		isoCode, _ := incoming["isoCode"].(string)
+		region, _ := incoming["region"].(string)
+		countries := stringList(incoming["countries"])
+		if region != "" || len(countries) > 0 {
+			// Switch to (or update) a multi-country or region registration
+			target := models.Registration{Country: country, IsoCode: isoCode, Region: region, Countries: countries}
+			if err := resolveMembers(&target); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
 			}
-		} else if isoCode, ok := incoming["isoCode"].(string); ok && isoCode != "" {
-			// Only ISO code is provided, validate it
-			if err := validateISOCode(existing.Country, isoCode); err != nil {
+			existing.Country, existing.IsoCode, existing.IsoInput = "", "", ""
+			existing.Region, existing.Countries, existing.Members = target.Region, target.Countries, target.Members
+		} else if country != "" || isoCode != "" {
+			name, canonical, err := resolveCountry(country, isoCode)
+			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			existing.IsoCode = isoCode // Update ISO code
+			existing.Country = name
+			existing.IsoCode = canonical
+			existing.IsoInput = isoCode
+			existing.Region, existing.Countries, existing.Members = "", nil, nil
+		}
+
+		// Update neighbour mode if present in the request
+		if neighbours, ok := incoming["neighbours"].(bool); ok {
+			existing.Neighbours = neighbours
+		}
+
+		// Update the timestamp settings if present in the request
+		if tz, ok := incoming["timezone"].(string); ok {
+			existing.Timezone = tz
+		}
+		if format, ok := incoming["timeFormat"].(string); ok {
+			existing.TimeFormat = format
+		}
+		if err := validateTimeSettings(&existing); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
 
 		// Update features if present in the request
 		if featuresRaw, ok := incoming["features"].(map[string]interface{}); ok {
 			existing.Features = updateFeaturesFromIncoming(existing.Features, featuresRaw)
+			if err := validateCurrencies(&existing.Features); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
 		}
 
 		// Update LastChange timestamp
@@ -291,10 +344,13 @@ func putRegistration(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Trigger webhook for the change event
-		services.TriggerWebhookEvent(constants.EventChange, existing.IsoCode)
+		// Trigger webhook for the change event, with the changed fields
+		triggerRegistrationEvent(constants.EventChange, &existing, &before, diffRegistrations(&before, &existing))
 
 		// Respond with updated data
+		if settings, err := requestTimeSettings(r, &existing); err == nil {
+			existing.LastChange = existing.LastChange.WithSettings(settings)
+		}
 		response := map[string]interface{}{
 			"message":     "Registration updated successfully",
 			"updatedData": existing,
@@ -326,85 +382,327 @@ func updateFeaturesFromIncoming(existing models.Features, featuresRaw map[string
 	if val, ok := featuresRaw["area"].(bool); ok {
 		existing.Area = val
 	}
-	if val, ok := featuresRaw["targetCurrencies"].([]interface{}); ok {
-		var currencies []string
-		for _, v := range val {
-			if s, ok := v.(string); ok {
-				currencies = append(currencies, s)
+	if val, ok := featuresRaw["languages"].(bool); ok {
+		existing.Languages = val
+	}
+	if val, ok := featuresRaw["borders"].(bool); ok {
+		existing.Borders = val
+	}
+	if val, ok := featuresRaw["timezones"].(bool); ok {
+		existing.Timezones = val
+	}
+	if val, ok := featuresRaw["region"].(bool); ok {
+		existing.Region = val
+	}
+	if val, ok := featuresRaw["flag"].(bool); ok {
+		existing.Flag = val
+	}
+	if val, ok := featuresRaw["callingCode"].(bool); ok {
+		existing.CallingCode = val
+	}
+	if val, ok := featuresRaw["drivingSide"].(bool); ok {
+		existing.DrivingSide = val
+	}
+	if val, ok := featuresRaw["topLevelDomain"].(bool); ok {
+		existing.TopLevelDomain = val
+	}
+	if val, ok := featuresRaw["populationDensity"].(bool); ok {
+		existing.PopulationDensity = val
+	}
+	if val, ok := featuresRaw["localTime"].(bool); ok {
+		existing.LocalTime = val
+	}
+	if val, ok := featuresRaw["daylight"].(bool); ok {
+		existing.Daylight = val
+	}
+	if _, ok := featuresRaw["targetCurrencies"].([]interface{}); ok {
+		existing.TargetCurrencies = stringList(featuresRaw["targetCurrencies"])
+	}
+	return existing
+}
+
+// stringList converts a decoded JSON array to a list of its string values.
+func stringList(raw interface{}) []string {
+	values, ok := raw.([]interface{})
+	if !ok {
+		return nil
+	}
+	var list []string
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// triggerRegistrationEvent triggers the webhooks for a registration event, once per country of the registration.
+// For CHANGE events, the countries before the update are included, so subscribers of a country that was
+// switched away from are notified too. The payloads carry a snapshot of the registration and the changed fields.
+func triggerRegistrationEvent(event string, registration, before *models.Registration, changes []models.FieldChange) {
+	countries := registrationCountries(registration)
+	if before != nil {
+		for _, code := range registrationCountries(before) {
+			if !slices.Contains(countries, code) {
+				countries = append(countries, code)
 			}
 		}
-		existing.TargetCurrencies = currencies
 	}
-	return existing
+	data := models.WebhookEventData{Registration: registration, Changes: changes}
+	services.PublishWebhookEvents(event, countries, data)
 }
 
-func validateRegistration(registration models.Registration) error {
-	if registration.Country == "" {
-		return fmt.Errorf(errorMessages.NoCountryProvided)
+// registrationCountries returns the ISO codes a registration is about,
+// one per member for multi-country and region registrations.
+func registrationCountries(registration *models.Registration) []string {
+	if len(registration.Members) > 0 {
+		return slices.Clone(registration.Members)
+	}
+	return []string{registration.IsoCode}
+}
+
+// validateRegistration checks the country and ISO code of a registration.
+// Only one of them is required, the other is resolved from REST Countries.
+// On success the country is set to its common name, the ISO code is normalized to cca2
+// and the client's original code is kept in IsoInput.
+func validateRegistration(registration *models.Registration) error {
+	if err := validateCurrencies(&registration.Features); err != nil {
+		return err
+	}
+	if err := validateTimeSettings(registration); err != nil {
+		return err
+	}
+
+	// Multi-country and region registrations target several countries instead of one
+	if registration.Region != "" || len(registration.Countries) > 0 {
+		return resolveMembers(registration)
 	}
 
-	if registration.IsoCode == "" {
-		return fmt.Errorf(errorMessages.IsoRequired)
+	if registration.Country == "" && registration.IsoCode == "" {
+		return fmt.Errorf(errorMessages.NoCountryOrISOProvided)
 	}
 
-	// Delegate ISO code validation to a dedicated function
-	if err := validateISOCode(registration.Country, registration.IsoCode); err != nil {
+	name, canonical, err := resolveCountry(registration.Country, registration.IsoCode)
+	if err != nil {
 		return err
 	}
 
+	registration.IsoInput = registration.IsoCode
+	registration.Country = name
+	registration.IsoCode = canonical
 	return nil
 }
-func validateISOCode(country string, isoCode string) error {
+
+// validateCurrencies upper-cases the target currencies and checks that the exchange-rate source supports them.
+// If the list of supported currencies can't be fetched, the currencies are accepted as they are.
+func validateCurrencies(features *models.Features) error {
+	for i, currency := range features.TargetCurrencies {
+		features.TargetCurrencies[i] = strings.ToUpper(strings.TrimSpace(currency))
+	}
+	if len(features.TargetCurrencies) == 0 {
+		return nil
+	}
+
+	unsupported, err := services.UnsupportedCurrencies(features.TargetCurrencies)
+	if err != nil {
+		log.Printf("Skipping currency validation: %v", err)
+		return nil
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("%s: %s", errorMessages.UnsupportedCurrencies, strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
+// resolveMembers resolves the countries of a multi-country or region registration to their ISO codes.
+// The country list is normalized to common names, and Members is set to the ISO codes of all countries.
+func resolveMembers(registration *models.Registration) error {
+	if registration.Country != "" || registration.IsoCode != "" || (registration.Region != "" && len(registration.Countries) > 0) {
+		return fmt.Errorf(errorMessages.ConflictingTargets)
+	}
+
+	registration.Members = nil
+	if registration.Region != "" {
+		members, err := services.GetRegionMembers(registration.Region)
+		if errors.Is(err, services.ErrRegionNotFound) {
+			return fmt.Errorf("%s: %s", errorMessages.RegionNotFound, registration.Region)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", errorMessages.APIFailed, err)
+		}
+		for _, member := range members {
+			registration.Members = append(registration.Members, member.ISOCode)
+		}
+		return nil
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, entry := range registration.Countries {
+		// Entries can be names, aliases or ISO codes
+		country, isoCode := entry, ""
+		if _, isAlias := services.LookupCountryAlias(entry); services.IsCountryCode(entry) && !isAlias {
+			country, isoCode = "", entry
+		}
+		name, code, err := resolveCountry(country, isoCode)
+		if err != nil {
+			return err
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		names = append(names, name)
+		registration.Members = append(registration.Members, code)
+	}
+	registration.Countries = names
+	return nil
+}
+
+// resolveCountry returns the common country name and canonical cca2 code from a country name, an ISO code or both.
+// When both are given they must refer to the same country.
+func resolveCountry(country, isoCode string) (string, string, error) {
+	if isoCode != "" && !services.IsCountryCode(isoCode) {
+		return "", "", fmt.Errorf("%s: '%s'", errorMessages.InvalidISOCode, isoCode)
+	}
+
+	// Aliases and alternative spellings ("UK", "Holland") are resolved to an ISO code first
+	if code, ok := lookupCountryCode(country); ok {
+		info, err := services.GetCountryInfoByCode(code)
+		if err != nil {
+			return "", "", fmt.Errorf("%s: %v", errorMessages.APIFailed, err)
+		}
+		if isoCode != "" && !services.CountryCodeMatches(info, isoCode) {
+			return "", "", fmt.Errorf("%s: %s", errorMessages.ISOCodeMismatch, fmt.Sprintf("ISO code '%s' does not match country '%s' (expected '%s')", isoCode, country, info.ISOCode))
+		}
+		return commonNameOr(info.Name, country), strings.ToUpper(info.ISOCode), nil
+	}
+
+	// Both given, the ISO code has to match the country
+	if country != "" && isoCode != "" {
+		info, err := validateISOCode(country, isoCode)
+		if err != nil {
+			return "", "", err
+		}
+		return commonNameOr(info.Name, country), info.ISOCode, nil
+	}
+
+	var info *models.CountryInfo
+	var err error
+	if isoCode != "" {
+		// Only ISO code given, look up the country it belongs to
+		info, err = services.GetCountryInfoByCode(isoCode)
+		if errors.Is(err, services.ErrCountryNotFound) {
+			return "", "", fmt.Errorf("%s: %s", errorMessages.APINotFound, isoCode)
+		}
+	} else {
+		// Only country name given, look up its ISO code
+		info, err = services.GetCountryInfo(country)
+		if errors.Is(err, services.ErrCountryNotFound) {
+			return "", "", countryNotFoundError(country)
+		}
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %v", errorMessages.APIFailed, err)
+	}
+	if info.ISOCode == "" {
+		return "", "", fmt.Errorf("%s: ISO code (cca2) not found in API response", errorMessages.InvalidISOCodeFormat)
+	}
+
+	return commonNameOr(info.Name, country), strings.ToUpper(info.ISOCode), nil
+}
+
+// lookupCountryCode resolves a country alias or alternative spelling to its ISO alpha-2 code.
+func lookupCountryCode(country string) (string, bool) {
+	if country == "" {
+		return "", false
+	}
+	if code, ok := services.LookupCountryAlias(country); ok {
+		return code, true
+	}
+	if match, ok := services.FindCountry(country); ok {
+		return match.ISOCode, true
+	}
+	return "", false
+}
+
+// countryNotFoundError builds the not found error for a country, with "did you mean" suggestions when there are any.
+func countryNotFoundError(country string) error {
+	suggestions := services.SuggestCountries(country, constants.MaxCountrySuggestions)
+	if len(suggestions) == 0 {
+		return fmt.Errorf("%s: %s", errorMessages.APINotFound, country)
+	}
+	names := make([]string, 0, len(suggestions))
+	for _, s := range suggestions {
+		names = append(names, s.Name)
+	}
+	return fmt.Errorf("%s: %s (%s: %s?)", errorMessages.APINotFound, country, errorMessages.DidYouMean, strings.Join(names, ", "))
+}
+
+// commonNameOr returns the common name from REST Countries, or the fallback if the API did not return one.
+func commonNameOr(name, fallback string) string {
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+// validateISOCode checks that isoCode belongs to the given country and returns its common name and canonical cca2 code.
+// The ISO code may be given as alpha-2 (cca2), alpha-3 (cca3) or numeric (ccn3).
+func validateISOCode(country string, isoCode string) (*models.CountryInfo, error) {
+	if !services.IsCountryCode(isoCode) {
+		return nil, fmt.Errorf("%s: '%s'", errorMessages.InvalidISOCode, isoCode)
+	}
+
 	// Build the request URL and perform the HTTP GET request
 	apiURL := fmt.Sprintf(constants.RestCountriesAPI+"/name/%s", country)
 	fmt.Println("VALIDATING AGAINST:", apiURL) // DEBUG LINE
 	resp, err := http.Get(apiURL)
 	if err != nil {
-		return fmt.Errorf("%s: %v", errorMessages.APIFailed, err)
+		return nil, fmt.Errorf("%s: %v", errorMessages.APIFailed, err)
 	}
 	defer resp.Body.Close()
 
 	// Handle specific error for 404 (not found)
 	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("%s: %s", errorMessages.APINotFound, country)
+		return nil, countryNotFoundError(country)
 	}
 	// Generic error for other non-200 responses
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%s: %s", errorMessages.APIUnexpectedStatus, resp.Status)
+		return nil, fmt.Errorf("%s: %s", errorMessages.APIUnexpectedStatus, resp.Status)
 	}
 
 	// Decode the JSON response
 	var apiResponse []map[string]interface{}
 	err = json.NewDecoder(resp.Body).Decode(&apiResponse)
 	if err != nil {
-		return fmt.Errorf("failed to decode external API response: %v", err)
+		return nil, fmt.Errorf("failed to decode external API response: %v", err)
 	}
 
 	// Check for data presence and extract cca2
 	if len(apiResponse) == 0 {
-		return fmt.Errorf("%s: %s", errorMessages.NoDataFoundForCountry, country)
+		return nil, fmt.Errorf("%s: %s", errorMessages.NoDataFoundForCountry, country)
 	}
 	cca2Raw, ok := apiResponse[0]["cca2"]
 	if !ok {
-		return fmt.Errorf("%s: ISO code (cca2) not found in API response", errorMessages.InvalidISOCodeFormat)
+		return nil, fmt.Errorf("%s: ISO code (cca2) not found in API response", errorMessages.InvalidISOCodeFormat)
 	}
 	cca2, ok := cca2Raw.(string)
 	if !ok {
-		return fmt.Errorf("%s: invalid ISO code format in API response", errorMessages.InvalidISOCodeFormat)
+		return nil, fmt.Errorf("%s: invalid ISO code format in API response", errorMessages.InvalidISOCodeFormat)
 	}
 
-	// Compare ISO codes, case-insensitively
-	if !strings.EqualFold(cca2, isoCode) {
-		return fmt.Errorf("%s: %s", errorMessages.ISOCodeMismatch, fmt.Sprintf("ISO code '%s' does not match country '%s' (expected '%s')", isoCode, country, cca2))
+	// Compare ISO codes against every equivalent form, case-insensitively
+	for _, field := range []string{"cca2", "cca3", "ccn3"} {
+		if code, ok := apiResponse[0][field].(string); ok && strings.EqualFold(code, isoCode) {
+			// Extract the common name so the registration stores the canonical one
+			name := ""
+			if names, ok := apiResponse[0]["name"].(map[string]interface{}); ok {
+				name, _ = names["common"].(string)
+			}
+			return &models.CountryInfo{Name: name, ISOCode: strings.ToUpper(cca2)}, nil
+		}
 	}
 
-	return nil
-}
-
-// validateCountryISO ensures the provided country and ISO code match.
-func validateCountryISO(country, isoCode string) error {
-	if err := validateISOCode(country, isoCode); err != nil {
-		return fmt.Errorf(errorMessages.IsoCodeDoesNotMatch+" %s", err.Error())
-	}
-	return nil
+	return nil, fmt.Errorf("%s: %s", errorMessages.ISOCodeMismatch, fmt.Sprintf("ISO code '%s' does not match country '%s' (expected '%s')", isoCode, country, cca2))
 }
@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/firestore"
 	"Country-Dashboard-Service/internal/models"
 	"Country-Dashboard-Service/internal/utils"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -20,7 +22,7 @@ func startMockCountryAPI(t *testing.T, iso string) func() {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`[{"cca2": "` + iso + `"}]`))
 	}))
-	constants.RestCountriesAPI = mock.URL
+	useMockAPI(t, &constants.RestCountriesAPI, mock.URL)
 	return mock.Close
 }
 
@@ -294,3 +296,112 @@ func TestGetSpecificRegistration_NotFound(t *testing.T) {
 		t.Errorf("Expected 404 Not Found, got %d", w.Code)
 	}
 }
+
+func TestPostRegistration_Alpha3AndNumericISO(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"name": {"common": "Norway"}, "cca2": "NO", "cca3": "NOR", "ccn3": "578"}]`))
+	}))
+	defer mock.Close()
+	useMockAPI(t, &constants.RestCountriesAPI, mock.URL)
+
+	for _, code := range []string{"NOR", "578", "no"} {
+		reg := models.Registration{Country: "Norway", IsoCode: code}
+		payload, _ := json.Marshal(reg)
+
+		req := httptest.NewRequest(http.MethodPost, constants.Registrations, bytes.NewReader(payload))
+		w := httptest.NewRecorder()
+
+		RegistrationsHandler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200 OK for ISO code %s, got %d", code, w.Code)
+		}
+
+		var resp map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		doc, err := firestore.Client.Collection("registrations").Doc(resp["id"].(string)).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to read stored registration: %v", err)
+		}
+		var stored models.Registration
+		if err := doc.DataTo(&stored); err != nil {
+			t.Fatalf("Failed to decode stored registration: %v", err)
+		}
+		if stored.IsoCode != "NO" || stored.IsoInput != code {
+			t.Errorf("expected canonical NO with input %s, got %s / %s", code, stored.IsoCode, stored.IsoInput)
+		}
+	}
+}
+
+func TestPostRegistration_MalformedISO(t *testing.T) {
+	closeMock := startMockCountryAPI(t, "NO")
+	defer closeMock()
+
+	reg := models.Registration{Country: "Norway", IsoCode: "NORWAY"}
+	payload, _ := json.Marshal(reg)
+
+	req := httptest.NewRequest(http.MethodPost, constants.Registrations, bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+
+	RegistrationsHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 Bad Request for malformed ISO code, got %d", w.Code)
+	}
+}
+
+func TestPostRegistration_ResolvesMissingCountryOrISO(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"name": {"common": "Norway"}, "cca2": "NO", "cca3": "NOR", "ccn3": "578"}]`))
+	}))
+	defer mock.Close()
+	useMockAPI(t, &constants.RestCountriesAPI, mock.URL)
+
+	for _, reg := range []models.Registration{{Country: "norway"}, {IsoCode: "NOR"}} {
+		payload, _ := json.Marshal(reg)
+
+		req := httptest.NewRequest(http.MethodPost, constants.Registrations, bytes.NewReader(payload))
+		w := httptest.NewRecorder()
+
+		RegistrationsHandler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200 OK for %+v, got %d", reg, w.Code)
+		}
+
+		var resp map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		doc, err := firestore.Client.Collection("registrations").Doc(resp["id"].(string)).Get(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to read stored registration: %v", err)
+		}
+		var stored models.Registration
+		if err := doc.DataTo(&stored); err != nil {
+			t.Fatalf("Failed to decode stored registration: %v", err)
+		}
+		if stored.Country != "Norway" || stored.IsoCode != "NO" {
+			t.Errorf("expected Norway/NO, got %s/%s", stored.Country, stored.IsoCode)
+		}
+	}
+}
+
+func TestPostRegistration_NoCountryOrISO(t *testing.T) {
+	payload, _ := json.Marshal(models.Registration{})
+
+	req := httptest.NewRequest(http.MethodPost, constants.Registrations, bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+
+	RegistrationsHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 Bad Request, got %d", w.Code)
+	}
+}
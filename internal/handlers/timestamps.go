@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/utils"
+	"fmt"
+	"net/http"
+)
+
+// requestTimeSettings returns the zone and format for the timestamps of a response.
+// The tz and timeFormat query parameters take precedence over the settings stored in the registration.
+func requestTimeSettings(r *http.Request, reg *models.Registration) (utils.TimeSettings, error) {
+	query := r.URL.Query()
+	tz, format := query.Get(constants.TimezoneParam), query.Get(constants.TimeFormatParam)
+	if tz == "" && format == "" && reg != nil {
+		tz, format = reg.Timezone, reg.TimeFormat
+	}
+
+	settings, err := utils.ParseTimeSettings(tz, format)
+	if err != nil {
+		return settings, fmt.Errorf("%s: %v", errorMessages.InvalidTimeSettings, err)
+	}
+	return settings, nil
+}
+
+// validateTimeSettings checks the timezone and time format stored in a registration.
+func validateTimeSettings(reg *models.Registration) error {
+	if reg.Timezone == "" && reg.TimeFormat == "" {
+		return nil
+	}
+	settings, err := utils.ParseTimeSettings(reg.Timezone, reg.TimeFormat)
+	if err != nil {
+		return fmt.Errorf("%s: %v", errorMessages.InvalidTimeSettings, err)
+	}
+	reg.TimeFormat = settings.Format
+	return nil
+}
+
+// applyTimeSettings sets the zone and format of the timestamps of a dashboard, its neighbours and members.
+func applyTimeSettings(dashboard *models.PopulatedDashboard, settings utils.TimeSettings) {
+	dashboard.LastRetrieval = dashboard.LastRetrieval.WithSettings(settings)
+	for i := range dashboard.Neighbours {
+		applyTimeSettings(&dashboard.Neighbours[i], settings)
+	}
+	for i := range dashboard.Members {
+		applyTimeSettings(&dashboard.Members[i], settings)
+	}
+}
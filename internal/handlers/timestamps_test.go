@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetPopulatedDashboard_TimezoneParam(t *testing.T) {
+	closeMock := startMockNordicCountriesAPI(t)
+	defer closeMock()
+
+	id := insertCountryRegistration(t, "Norway", models.Features{Capital: true})
+
+	req := httptest.NewRequest(http.MethodGet, constants.Dashboards+id+"?tz=America/New_York", nil)
+	rec := httptest.NewRecorder()
+
+	GetPopulatedDashboard(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d", rec.Code)
+	}
+
+	var raw map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&raw); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	retrieval, err := time.Parse(time.RFC3339, raw["lastRetrieval"].(string))
+	if err != nil {
+		t.Fatalf("Expected an RFC 3339 lastRetrieval, got %v", raw["lastRetrieval"])
+	}
+	if _, offset := retrieval.Zone(); offset != -4*3600 && offset != -5*3600 {
+		t.Errorf("Expected a New York offset, got %v", retrieval)
+	}
+}
+
+func TestGetPopulatedDashboard_InvalidTimezone(t *testing.T) {
+	id := insertCountryRegistration(t, "Norway", models.Features{Capital: true})
+
+	for _, query := range []string{"?tz=Mars/Olympus", "?timeFormat=unix", "?tz=UTC&timeFormat=legacy"} {
+		req := httptest.NewRequest(http.MethodGet, constants.Dashboards+id+query, nil)
+		rec := httptest.NewRecorder()
+
+		GetPopulatedDashboard(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400 Bad Request, got %d", query, rec.Code)
+		}
+	}
+}
+
+func TestRegistration_TimezoneSetting(t *testing.T) {
+	closeMock := startMockNordicCountriesAPI(t)
+	defer closeMock()
+
+	payload, _ := json.Marshal(models.Registration{Country: "Norway", Timezone: "UTC"})
+	req := httptest.NewRequest(http.MethodPost, constants.Registrations, bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+
+	RegistrationsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if lastChange, _ := resp["lastChange"].(string); !strings.HasSuffix(lastChange, "Z") {
+		t.Errorf("Expected lastChange in UTC, got %q", lastChange)
+	}
+}
+
+func TestRegistration_LastChangeRoundTrip(t *testing.T) {
+	closeMock := startMockNordicCountriesAPI(t)
+	defer closeMock()
+
+	before := time.Now().Add(-time.Second)
+	_, stored := postRegistration(t, models.Registration{Country: "Norway"})
+
+	// The default legacy format and RFC 3339 both decode back to the same instant
+	for _, query := range []string{"", "?timeFormat=rfc3339"} {
+		req := httptest.NewRequest(http.MethodGet, constants.Registrations+stored.ID+query, nil)
+		w := httptest.NewRecorder()
+
+		RegistrationsHandler(w, req)
+
+		var reg models.Registration
+		if err := json.NewDecoder(w.Body).Decode(&reg); err != nil {
+			t.Fatalf("%q: failed to decode registration: %v", query, err)
+		}
+		if reg.LastChange.Before(before) || reg.LastChange.After(time.Now()) {
+			t.Errorf("%q: unexpected lastChange %v", query, reg.LastChange.Time)
+		}
+	}
+}
+
+func TestRegistration_InvalidTimezoneSetting(t *testing.T) {
+	code, _ := postRegistration(t, models.Registration{Country: "Norway", Timezone: "Nowhere/Town"})
+	if code != http.StatusBadRequest {
+		t.Errorf("Expected 400 Bad Request, got %d", code)
+	}
+}
+
+func TestGetAllRegistrations_InvalidTimezone(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, constants.Registrations+"?tz=Nowhere/Town", nil)
+	w := httptest.NewRecorder()
+
+	RegistrationsHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 Bad Request, got %d", w.Code)
+	}
+}
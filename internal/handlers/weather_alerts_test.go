@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// startMockAlertWeatherAPI sets up a weather API answering with the stored hourly temperatures
+func startMockAlertWeatherAPI(t *testing.T, temperatures *atomic.Value) func() {
+	t.Helper()
+
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"hourly": {"temperature_2m": ` + temperatures.Load().(string) + `, "precipitation": [0, 0]}}`))
+	}))
+	useMockAPI(t, &constants.OpenMeteoAPI, mock.URL)
+	return mock.Close
+}
+
+func TestWeatherAlert_TriggersOnceAndClears(t *testing.T) {
+	closeCountries := startMockNordicCountriesAPI(t)
+	defer closeCountries()
+	var temperatures atomic.Value
+	temperatures.Store(`[-15, -12]`)
+	closeWeather := startMockAlertWeatherAPI(t, &temperatures)
+	defer closeWeather()
+	receiver, payloads := startPayloadReceiver(t)
+	defer receiver.Close()
+	id := postSubscription(t, models.WebhookRegistration{
+		URL:       receiver.URL,
+		Event:     constants.EventWeatherAlert,
+		Country:   "NO",
+		Condition: &models.WeatherCondition{Metric: "temperature", Operator: "below", Threshold: -10},
+	})
+
+	services.EvaluateWeatherAlerts()
+	payload := awaitPayload(t, payloads)
+	if payload.Alert == nil || payload.Alert.State != constants.AlertTriggered || payload.Alert.Value != -13.5 {
+		t.Fatalf("Expected a triggered alert at -13.5, got %+v", payload.Alert)
+	}
+
+	// A condition that keeps holding is not notified again
+	services.EvaluateWeatherAlerts()
+	if events := queuedEvents(t, id); len(events) != 1 {
+		t.Errorf("Expected a single alert while the condition holds, got %v", events)
+	}
+
+	temperatures.Store(`[2, 4]`)
+	services.EvaluateWeatherAlerts()
+	payload = awaitPayload(t, payloads)
+	if payload.Alert == nil || payload.Alert.State != constants.AlertCleared || payload.Alert.Value != 3 {
+		t.Errorf("Expected a cleared alert at 3, got %+v", payload.Alert)
+	}
+}
+
+func TestWeatherAlert_Validation(t *testing.T) {
+	condition := &models.WeatherCondition{Metric: "temperature", Operator: "below", Threshold: -10}
+	invalid := []models.WebhookRegistration{
+		{Event: constants.EventWeatherAlert, Country: "NO"},                                         // No condition
+		{Event: constants.EventWeatherAlert, Condition: condition},                                  // No country
+		{Event: constants.EventWeatherAlert, Countries: []string{"NO", "SE"}, Condition: condition}, // Several countries
+		{Event: constants.EventWeatherAlert, Country: "NO", Condition: &models.WeatherCondition{Metric: "wind", Operator: "above"}},
+		{Event: constants.EventInvoke, Country: "NO", Condition: condition}, // Condition without WEATHER_ALERT
+	}
+	for _, webhook := range invalid {
+		webhook.URL = "https://example.com/hook"
+		body, _ := json.Marshal(webhook)
+		w := httptest.NewRecorder()
+		NotificationsHandler(w, httptest.NewRequest(http.MethodPost, constants.Notifications, bytes.NewReader(body)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 Bad Request for %+v, got %d", webhook, w.Code)
+		}
+	}
+}
+
+func TestWeatherAlert_ResolvesCountry(t *testing.T) {
+	closeMock := startMockCatalogueAPI(t)
+	defer closeMock()
+	condition := &models.WeatherCondition{Metric: "temperature", Operator: "below", Threshold: -10}
+
+	webhook := models.WebhookRegistration{URL: "https://example.com/hook", Event: constants.EventWeatherAlert, Country: "Holland", Condition: condition}
+	if err := validateWebhook(&webhook); err != nil || webhook.Country != "NL" {
+		t.Errorf("Expected Holland to be stored as NL, got %q (%v)", webhook.Country, err)
+	}
+
+	webhook = models.WebhookRegistration{URL: "https://example.com/hook", Event: constants.EventWeatherAlert, Country: "Atlantis", Condition: condition}
+	body, _ := json.Marshal(webhook)
+	w := httptest.NewRecorder()
+	NotificationsHandler(w, httptest.NewRequest(http.MethodPost, constants.Notifications, bytes.NewReader(body)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 Bad Request for an unknown country, got %d", w.Code)
+	}
+}
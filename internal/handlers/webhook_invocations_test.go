@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/firestore"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestInvocations_CountedAtomically(t *testing.T) {
+	firestore.Client.Collection("invocations").Doc("FO").Delete(context.Background())
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[int64]bool)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count, err := firestore.IncrementInvocations("fo")
+			if err != nil {
+				t.Errorf("Failed to count invocation: %v", err)
+				return
+			}
+			mu.Lock()
+			seen[count] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	for count := int64(1); count <= 5; count++ {
+		if !seen[count] {
+			t.Errorf("Expected every invocation to get its own count, got %v", seen)
+			break
+		}
+	}
+}
+
+func TestInvocations_NotifyEveryN(t *testing.T) {
+	firestore.Client.Collection("invocations").Doc("GL").Delete(context.Background())
+	receiver := startStatusReceiver(t, http.StatusOK)
+	defer receiver.Close()
+	every := postSubscription(t, models.WebhookRegistration{URL: receiver.URL, Event: constants.EventInvoke, Country: "GL", InvokeEvery: 3})
+	always := postSubscription(t, models.WebhookRegistration{URL: receiver.URL, Event: constants.EventInvoke, Country: "GL"})
+
+	for i := 0; i < 7; i++ {
+		services.PublishInvokeEvents([]string{"GL"}, models.WebhookEventData{})
+	}
+
+	if events := queuedEvents(t, always); len(events) != 7 {
+		t.Errorf("Expected a delivery per invocation without invokeEvery, got %d", len(events))
+	}
+	deliveries, err := firestore.ListDeliveries("", every)
+	if err != nil {
+		t.Fatalf("Failed to list deliveries: %v", err)
+	}
+	if len(deliveries) != 2 {
+		t.Fatalf("Expected deliveries at the 3rd and 6th invocation, got %d", len(deliveries))
+	}
+	for i, delivery := range deliveries {
+		var payload models.WebhookPayload
+		if err := json.Unmarshal([]byte(delivery.Payload), &payload); err != nil {
+			t.Fatalf("Failed to decode payload: %v", err)
+		}
+		if want := int64(3 * (i + 1)); payload.Invocations != want {
+			t.Errorf("Expected invocations %d in the payload, got %d", want, payload.Invocations)
+		}
+	}
+}
+
+func TestInvocations_InvalidInvokeEvery(t *testing.T) {
+	for _, webhook := range []models.WebhookRegistration{
+		{URL: "https://example.com/hook", Event: constants.EventInvoke, InvokeEvery: -1},
+		{URL: "https://example.com/hook", Event: constants.EventRegister, InvokeEvery: 50},
+	} {
+		body, _ := json.Marshal(webhook)
+		w := httptest.NewRecorder()
+		NotificationsHandler(w, httptest.NewRequest(http.MethodPost, constants.Notifications, bytes.NewReader(body)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 Bad Request for %+v, got %d", webhook, w.Code)
+		}
+	}
+}
+
+func TestInvocations_OnlyCountedForInvokeEvery(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusOK)
+	defer receiver.Close()
+	id := postSubscription(t, models.WebhookRegistration{URL: receiver.URL, Event: constants.EventInvoke, Country: "AX"})
+
+	services.PublishInvokeEvents([]string{"AX"}, models.WebhookEventData{})
+
+	if events := queuedEvents(t, id); len(events) != 1 {
+		t.Errorf("Expected the invocation to be notified, got %v", events)
+	}
+	doc, _ := firestore.Client.Collection("invocations").Doc("AX").Get(context.Background())
+	if doc != nil && doc.Exists() {
+		t.Errorf("Expected no counter for a country without an invokeEvery subscription")
+	}
+}
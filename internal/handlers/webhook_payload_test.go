@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startPayloadReceiver starts a webhook receiver passing on every payload it gets
+func startPayloadReceiver(t *testing.T) (*httptest.Server, chan models.WebhookPayload) {
+	t.Helper()
+
+	payloads := make(chan models.WebhookPayload, 4)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload models.WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode webhook payload: %v", err)
+		}
+		payloads <- payload
+	}))
+	return receiver, payloads
+}
+
+// awaitPayload waits for the next payload
+func awaitPayload(t *testing.T, payloads chan models.WebhookPayload) models.WebhookPayload {
+	t.Helper()
+
+	select {
+	case payload := <-payloads:
+		return payload
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for webhook event")
+	}
+	return models.WebhookPayload{}
+}
+
+func TestDiffRegistrations(t *testing.T) {
+	before := models.Registration{ID: "abc", Country: "Norway", IsoCode: "NO", Features: models.Features{Temperature: true, TargetCurrencies: []string{"EUR"}}}
+	after := before
+	after.Country, after.IsoCode = "Sweden", "SE"
+	after.Features.Temperature = false
+	after.Features.TargetCurrencies = []string{"EUR", "USD"}
+	after.Timezone = "UTC"
+
+	changes := diffRegistrations(&before, &after)
+
+	var fields []string
+	for _, change := range changes {
+		fields = append(fields, change.Field)
+	}
+	want := "country,features.targetCurrencies,features.temperature,isoCode,timezone"
+	if strings.Join(fields, ",") != want {
+		t.Fatalf("Expected changes to %s, got %v", want, fields)
+	}
+	if changes[2].Before != true || changes[2].After != false {
+		t.Errorf("Expected temperature to change from true to false, got %+v", changes[2])
+	}
+	if changes[4].Before != nil || changes[4].After != "UTC" {
+		t.Errorf("Expected the added timezone to have no previous value, got %+v", changes[4])
+	}
+}
+
+func TestPutRegistration_ChangePayload(t *testing.T) {
+	receiver, payloads := startPayloadReceiver(t)
+	defer receiver.Close()
+	postSubscription(t, models.WebhookRegistration{URL: receiver.URL, Event: constants.EventChange})
+	id := insertCountryRegistration(t, "Norway", models.Features{Temperature: true})
+
+	body := strings.NewReader(`{"features": {"temperature": false, "capital": true}}`)
+	w := httptest.NewRecorder()
+	RegistrationsHandler(w, httptest.NewRequest(http.MethodPut, constants.Registrations+id, body))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+
+	payload := awaitPayload(t, payloads)
+	if payload.RegistrationID != id || payload.Registration == nil || !payload.Registration.Features.Capital {
+		t.Fatalf("Expected a snapshot of the updated registration %s, got %+v", id, payload)
+	}
+	changed := make(map[string]models.FieldChange)
+	for _, change := range payload.Changes {
+		changed[change.Field] = change
+	}
+	if len(changed) != 2 || changed["features.capital"].After != true || changed["features.temperature"].Before != true {
+		t.Errorf("Expected capital and temperature to change, got %+v", payload.Changes)
+	}
+}
+
+func TestGetPopulatedDashboard_InvokePayloadDashboard(t *testing.T) {
+	closeMock := startMockNordicCountriesAPI(t)
+	defer closeMock()
+	withDashboard, withDashboardPayloads := startPayloadReceiver(t)
+	defer withDashboard.Close()
+	plain, plainPayloads := startPayloadReceiver(t)
+	defer plain.Close()
+	postSubscription(t, models.WebhookRegistration{URL: withDashboard.URL, Event: constants.EventInvoke, Country: "NO", IncludeDashboard: true})
+	postSubscription(t, models.WebhookRegistration{URL: plain.URL, Event: constants.EventInvoke, Country: "NO"})
+	id := insertCountryRegistration(t, "Norway", models.Features{Capital: true})
+
+	w := httptest.NewRecorder()
+	GetPopulatedDashboard(w, httptest.NewRequest(http.MethodGet, constants.Dashboards+id, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+
+	payload := awaitPayload(t, withDashboardPayloads)
+	if payload.RegistrationID != id || payload.Dashboard == nil || payload.Dashboard.Features.Capital != "Oslo" {
+		t.Errorf("Expected the populated dashboard in the payload, got %+v", payload)
+	}
+	if payload = awaitPayload(t, plainPayloads); payload.Dashboard != nil || payload.RegistrationID != id {
+		t.Errorf("Expected the registration but no dashboard without includeDashboard, got %+v", payload)
+	}
+}
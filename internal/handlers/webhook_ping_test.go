@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/pkg/webhooksig"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// testFire calls POST /notifications/{id}/test and decodes the result
+func testFire(t *testing.T, id string) (int, models.WebhookTestResult) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, constants.Notifications+id+"/test", nil)
+	w := httptest.NewRecorder()
+	NotificationsHandler(w, req)
+
+	var result models.WebhookTestResult
+	if w.Code == http.StatusOK {
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	}
+	return w.Code, result
+}
+
+func TestNotificationTest_Delivered(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := webhooksig.VerifyRequest(r, webhooksig.DefaultTolerance, "secret")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		var payload models.WebhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil || !payload.Test {
+			http.Error(w, "not a test event", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("pong"))
+	}))
+	defer receiver.Close()
+	id := insertSignedWebhook(t, receiver.URL, "secret")
+
+	code, result := testFire(t, id)
+
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200 OK, got %d", code)
+	}
+	if !result.Success || result.StatusCode != http.StatusAccepted || result.ResponseBody != "pong" {
+		t.Errorf("Expected an accepted, signed test delivery, got %+v", result)
+	}
+	if result.Event != constants.EventInvoke || result.DeliveryStatus != constants.DeliveryDelivered {
+		t.Errorf("Expected a delivered INVOKE event, got %+v", result)
+	}
+}
+
+func TestNotificationTest_ReceiverFailure(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusInternalServerError)
+	defer receiver.Close()
+	id := insertSignedWebhook(t, receiver.URL, "secret")
+
+	code, result := testFire(t, id)
+
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200 OK, got %d", code)
+	}
+	// Server errors are retried like any other delivery
+	if result.Success || result.StatusCode != http.StatusInternalServerError || result.DeliveryStatus != constants.DeliveryPending {
+		t.Errorf("Expected a failed delivery scheduled for a retry, got %+v", result)
+	}
+}
+
+func TestNotificationTest_UnknownWebhook(t *testing.T) {
+	if code, _ := testFire(t, "does-not-exist"); code != http.StatusNotFound {
+		t.Errorf("Expected status 404 Not Found, got %d", code)
+	}
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/firestore"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"Country-Dashboard-Service/pkg/webhooksig"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startSignedReceiver starts a webhook receiver that reports whether each delivery verifies with the given secret
+func startSignedReceiver(t *testing.T, secret string) (*httptest.Server, chan error) {
+	t.Helper()
+
+	results := make(chan error, 4)
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := webhooksig.VerifyRequest(r, webhooksig.DefaultTolerance, secret)
+		results <- err
+	}))
+	return mock, results
+}
+
+// insertSignedWebhook stores a webhook with the given secret for INVOKE events in Sweden
+func insertSignedWebhook(t *testing.T, url, secret string) string {
+	t.Helper()
+
+	webhook := models.WebhookRegistration{URL: url, Country: "SE", Event: constants.EventInvoke, Secret: secret}
+	docRef, _, err := firestore.Client.Collection("notifications").Add(context.Background(), webhook)
+	if err != nil {
+		t.Fatalf("Failed to insert webhook: %v", err)
+	}
+	return docRef.ID
+}
+
+// awaitVerification waits for a delivery and fails the test if it did not verify
+func awaitVerification(t *testing.T, results chan error) {
+	t.Helper()
+
+	select {
+	case err := <-results:
+		if err != nil {
+			t.Errorf("Expected a valid signature, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for webhook event")
+	}
+}
+
+func TestPostNotification_GeneratesSecret(t *testing.T) {
+	payload, _ := json.Marshal(models.WebhookRegistration{URL: "https://localhost:9999/webhook", Event: constants.EventRegister})
+	req := httptest.NewRequest(http.MethodPost, constants.Notifications, bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+
+	NotificationsHandler(w, req)
+
+	var resp map[string]string
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !strings.HasPrefix(resp["secret"], constants.WebhookSecretPrefix) {
+		t.Fatalf("Expected a generated secret, got %q", resp["secret"])
+	}
+
+	// The secret is not shown again
+	req = httptest.NewRequest(http.MethodGet, constants.Notifications+resp["id"], nil)
+	w = httptest.NewRecorder()
+	NotificationsHandler(w, req)
+	if strings.Contains(w.Body.String(), resp["secret"]) {
+		t.Errorf("Expected the secret to be hidden, got %s", w.Body.String())
+	}
+}
+
+func TestTriggerWebhookEvent_SignsDelivery(t *testing.T) {
+	receiver, results := startSignedReceiver(t, "s3cret")
+	defer receiver.Close()
+	insertSignedWebhook(t, receiver.URL, "s3cret")
+
+	services.TriggerWebhookEvent(constants.EventInvoke, "SE")
+
+	awaitVerification(t, results)
+}
+
+func TestRotateNotificationSecret(t *testing.T) {
+	oldReceiver, oldResults := startSignedReceiver(t, "old-secret")
+	defer oldReceiver.Close()
+	id := insertSignedWebhook(t, oldReceiver.URL, "old-secret")
+
+	body := strings.NewReader(`{"secret": "new-secret", "gracePeriod": "1h"}`)
+	req := httptest.NewRequest(http.MethodPost, constants.Notifications+id+"/rotate-secret", body)
+	w := httptest.NewRecorder()
+
+	NotificationsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 OK, got %d", w.Code)
+	}
+
+	// During the grace period the delivery is signed with both secrets, so the receiver still accepts it
+	services.TriggerWebhookEvent(constants.EventInvoke, "SE")
+	awaitVerification(t, oldResults)
+}
+
+func TestRotateNotificationSecret_InvalidGracePeriod(t *testing.T) {
+	id := insertSignedWebhook(t, "https://localhost:9999/webhook", "secret")
+
+	req := httptest.NewRequest(http.MethodPost, constants.Notifications+id+"/rotate-secret", strings.NewReader(`{"gracePeriod": "30d"}`))
+	w := httptest.NewRecorder()
+
+	NotificationsHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 Bad Request, got %d", w.Code)
+	}
+}
+
+func TestRotateNotificationSecret_Twice(t *testing.T) {
+	oldReceiver, oldResults := startSignedReceiver(t, "first-secret")
+	defer oldReceiver.Close()
+	id := insertSignedWebhook(t, oldReceiver.URL, "first-secret")
+
+	for _, secret := range []string{"second-secret", "third-secret"} {
+		body := strings.NewReader(`{"secret": "` + secret + `", "gracePeriod": "1h"}`)
+		w := httptest.NewRecorder()
+		NotificationsHandler(w, httptest.NewRequest(http.MethodPost, constants.Notifications+id+"/rotate-secret", body))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 OK, got %d", w.Code)
+		}
+	}
+
+	// The second rotation does not end the grace period of the first secret
+	services.TriggerWebhookEvent(constants.EventInvoke, "SE")
+	awaitVerification(t, oldResults)
+}
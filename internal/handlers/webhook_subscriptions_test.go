@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/firestore"
+	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/internal/services"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// postSubscription registers a webhook with the given body and returns its ID
+func postSubscription(t *testing.T, webhook models.WebhookRegistration) string {
+	t.Helper()
+
+	body, _ := json.Marshal(webhook)
+	w := httptest.NewRecorder()
+	NotificationsHandler(w, httptest.NewRequest(http.MethodPost, constants.Notifications, bytes.NewReader(body)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201 Created, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]string
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return resp["id"]
+}
+
+// queuedEvents returns the events queued for the webhook, in order
+func queuedEvents(t *testing.T, webhookID string) []string {
+	t.Helper()
+
+	deliveries, err := firestore.ListDeliveries("", webhookID)
+	if err != nil {
+		t.Fatalf("Failed to list deliveries: %v", err)
+	}
+	events := make([]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		events = append(events, delivery.Event+":"+delivery.Country)
+	}
+	return events
+}
+
+func TestSubscription_MultipleEventsAndCountries(t *testing.T) {
+	closeMock := startMockRegionAPI(t) // Catalogue used to match equivalent ISO codes
+	defer closeMock()
+	receiver := startStatusReceiver(t, http.StatusOK)
+	defer receiver.Close()
+	id := postSubscription(t, models.WebhookRegistration{
+		URL:       receiver.URL,
+		Events:    []string{"register", constants.EventDelete},
+		Countries: []string{"NO", "se"},
+	})
+
+	services.TriggerWebhookEvent(constants.EventDelete, "SWE")  // Equivalent ISO code for Sweden
+	services.TriggerWebhookEvent(constants.EventInvoke, "NO")   // Not subscribed
+	services.TriggerWebhookEvent(constants.EventRegister, "DK") // Not subscribed
+	services.TriggerWebhookEvent(constants.EventRegister, "NO")
+
+	events := queuedEvents(t, id)
+	if len(events) != 2 || events[0] != "DELETE:SWE" || events[1] != "REGISTER:NO" {
+		t.Errorf("Expected DELETE for Sweden and REGISTER for Norway, got %v", events)
+	}
+}
+
+func TestSubscription_Wildcard(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusOK)
+	defer receiver.Close()
+	id := postSubscription(t, models.WebhookRegistration{URL: receiver.URL, Events: []string{constants.EventChange, "*"}})
+
+	services.TriggerWebhookEvent(constants.EventChange, "FI")
+	services.TriggerWebhookEvent(constants.EventInvoke, "IS")
+
+	events := queuedEvents(t, id)
+	if len(events) != 2 {
+		t.Errorf("Expected the wildcard to match every event, got %v", events)
+	}
+
+	var stored models.WebhookRegistration
+	doc, _ := firestore.Client.Collection("notifications").Doc(id).Get(context.Background())
+	if err := doc.DataTo(&stored); err != nil || len(stored.Events) != 1 || stored.Events[0] != constants.EventWildcard {
+		t.Errorf("Expected the events to be stored as the wildcard only, got %v", stored.Events)
+	}
+}
+
+func TestSubscription_LegacyAndListMatchOnce(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusOK)
+	defer receiver.Close()
+	// A document matching both the legacy event query and the events query
+	webhook := models.WebhookRegistration{URL: receiver.URL, Event: constants.EventInvoke, Events: []string{constants.EventInvoke}}
+	docRef, _, err := firestore.Client.Collection("notifications").Add(context.Background(), webhook)
+	if err != nil {
+		t.Fatalf("Failed to insert webhook: %v", err)
+	}
+
+	services.TriggerWebhookEvent(constants.EventInvoke, "NO")
+
+	if events := queuedEvents(t, docRef.ID); len(events) != 1 {
+		t.Errorf("Expected a single delivery, got %v", events)
+	}
+}
+
+func TestSubscription_InvalidEvents(t *testing.T) {
+	for _, events := range [][]string{{"FOO"}, {constants.EventInvoke, "BAR"}, {}} {
+		body, _ := json.Marshal(models.WebhookRegistration{URL: "https://example.com/hook", Events: events})
+		w := httptest.NewRecorder()
+		NotificationsHandler(w, httptest.NewRequest(http.MethodPost, constants.Notifications, bytes.NewReader(body)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 Bad Request for %v, got %d", events, w.Code)
+		}
+	}
+}
+
+func TestSubscription_MemberRegistrationPerCountry(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusOK)
+	defer receiver.Close()
+	all := postSubscription(t, models.WebhookRegistration{URL: receiver.URL, Event: constants.EventChange})
+	sweden := postSubscription(t, models.WebhookRegistration{URL: receiver.URL, Event: constants.EventChange, Country: "SE"})
+	finland := postSubscription(t, models.WebhookRegistration{URL: receiver.URL, Event: constants.EventChange, Country: "FI"})
+
+	registration := &models.Registration{ID: "nordic", Region: "Scandinavia", Members: []string{"DK", "NO", "SE"}}
+	triggerRegistrationEvent(constants.EventChange, registration, nil, nil)
+
+	if events := queuedEvents(t, all); len(events) != 3 {
+		t.Errorf("Expected an event per member country, got %v", events)
+	}
+	if events := queuedEvents(t, sweden); len(events) != 1 || events[0] != constants.EventChange+":SE" {
+		t.Errorf("Expected one event for Sweden, got %v", events)
+	}
+	if events := queuedEvents(t, finland); len(events) != 0 {
+		t.Errorf("Expected no event for a country outside the members, got %v", events)
+	}
+}
+
+func TestSubscription_ChangeNotifiesPreviousCountry(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusOK)
+	defer receiver.Close()
+	iceland := postSubscription(t, models.WebhookRegistration{URL: receiver.URL, Event: constants.EventChange, Country: "IS"})
+
+	before := &models.Registration{ID: "switched", Country: "Iceland", IsoCode: "IS"}
+	after := &models.Registration{ID: "switched", Region: "Western Africa", Members: []string{"GH", "SN"}}
+	triggerRegistrationEvent(constants.EventChange, after, before, diffRegistrations(before, after))
+
+	if events := queuedEvents(t, iceland); len(events) != 1 || events[0] != constants.EventChange+":IS" {
+		t.Errorf("Expected the previous country to be notified of the switch, got %v", events)
+	}
+}
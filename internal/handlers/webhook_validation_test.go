@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"Country-Dashboard-Service/constants"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// postWebhook registers a webhook with the given URL and event
+func postWebhook(t *testing.T, url, event string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]string{"url": url, "event": event})
+	req := httptest.NewRequest(http.MethodPost, constants.Notifications, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	NotificationsHandler(w, req)
+	return w
+}
+
+func TestPostNotification_ValidatesEvent(t *testing.T) {
+	if w := postWebhook(t, "https://example.com/hook", "FOO"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 Bad Request for an unknown event, got %d", w.Code)
+	}
+	// Events are case-insensitive
+	if w := postWebhook(t, "https://example.com/hook", "invoke"); w.Code != http.StatusCreated {
+		t.Errorf("Expected status 201 Created, got %d", w.Code)
+	}
+}
+
+func TestPostNotification_ValidatesURL(t *testing.T) {
+	for _, url := range []string{"", "/relative/path", "ftp://example.com/hook", "https://", "example.com/hook"} {
+		if w := postWebhook(t, url, constants.EventInvoke); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 Bad Request for %q, got %d", url, w.Code)
+		}
+	}
+}
+
+func TestDelivery_BlocksInternalAddresses(t *testing.T) {
+	// Cloud metadata endpoints in the link-local and the carrier-grade NAT range, and "this network"
+	for _, url := range []string{"http://169.254.169.254/latest/meta-data/", "http://100.100.100.200/latest/meta-data/", "http://0.1.2.3/"} {
+		id := insertSignedWebhook(t, url, "secret")
+
+		_, result := testFire(t, id)
+
+		// Blocked addresses are dead-lettered right away instead of retried
+		if result.Success || result.DeliveryStatus != constants.DeliveryDead || !strings.Contains(result.Error, "not allowed") {
+			t.Errorf("Expected %s to be blocked, got %+v", url, result)
+		}
+	}
+}
+
+func TestDelivery_AllowlistIsRequiredForLoopback(t *testing.T) {
+	receiver := startStatusReceiver(t, http.StatusOK)
+	defer receiver.Close()
+	id := insertSignedWebhook(t, receiver.URL, "secret")
+
+	t.Setenv(constants.EnvWebhookAllowedNetworks, "")
+	if _, result := testFire(t, id); result.Success {
+		t.Errorf("Expected the loopback receiver to be blocked without an allowlist, got %+v", result)
+	}
+
+	t.Setenv(constants.EnvWebhookAllowedNetworks, "127.0.0.0/8")
+	if _, result := testFire(t, id); !result.Success {
+		t.Errorf("Expected the allowlisted receiver to get the delivery, got %+v", result)
+	}
+}
@@ -0,0 +1,26 @@
+package models
+
+import "Country-Dashboard-Service/internal/utils"
+
+// Side-by-side comparison of several populated dashboards.
+type DashboardComparison struct {
+	Dashboards    map[string]PopulatedDashboard `json:"dashboards"`         // Populated dashboards keyed by registration ID
+	Features      map[string]FeatureComparison  `json:"features"`           // Comparison table keyed by feature name
+	Rankings      map[string]RankedDashboard    `json:"rankings,omitempty"` // Named rankings, e.g. "warmest" or "largest"
+	LastRetrieval utils.CustomTime              `json:"lastRetrieval"`
+}
+
+// Values of a single feature across the compared dashboards.
+type FeatureComparison struct {
+	Values  map[string]interface{} `json:"values"`            // Feature value keyed by registration ID
+	Delta   float64                `json:"delta,omitempty"`   // Difference between the highest and lowest numeric value
+	Ranking []string               `json:"ranking,omitempty"` // Registration IDs ordered from highest to lowest value
+}
+
+// The dashboard that came out on top in a named ranking.
+type RankedDashboard struct {
+	ID      string  `json:"id"`      // Registration ID
+	Country string  `json:"country"` // Country name
+	Feature string  `json:"feature"` // Feature the ranking is based on
+	Value   float64 `json:"value"`   // Value of the feature
+}
@@ -3,11 +3,28 @@ package models
 // CountryInfo holds basic country data used to populate the dashboard.
 type CountryInfo struct {
 	Name       string
-	ISOCode    string
+	ISOCode    string // ISO 3166-1 alpha-2 (cca2), used as the canonical code
+	ISOAlpha3  string // ISO 3166-1 alpha-3 (cca3)
+	ISONumeric string // ISO 3166-1 numeric (ccn3)
 	Capital    string
+	CapitalLat float64 // Coordinates of the capital, zero if unknown
+	CapitalLon float64
 	Latitude   float64
 	Longitude  float64
 	Population int
 	Area       float64
 	Currency   string
+
+	// Extended facts, only shown when the matching feature is enabled
+	Languages       map[string]string
+	Borders         []string
+	Timezones       []string
+	Region          string
+	Subregion       string
+	FlagEmoji       string
+	FlagPNG         string
+	FlagSVG         string
+	CallingCodes    []string
+	DrivingSide     string
+	TopLevelDomains []string
 }
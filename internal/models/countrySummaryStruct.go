@@ -0,0 +1,22 @@
+package models
+
+// CountrySummary is a compact entry in the cached country catalogue built from REST Countries.
+type CountrySummary struct {
+	Name         string            `json:"name"`                   // Common name of the country
+	OfficialName string            `json:"officialName,omitempty"` // Official name of the country
+	ISOCode      string            `json:"isoCode"`                // ISO 3166-1 alpha-2 (cca2)
+	ISOAlpha3    string            `json:"isoAlpha3,omitempty"`    // ISO 3166-1 alpha-3 (cca3)
+	ISONumeric   string            `json:"isoNumeric,omitempty"`   // ISO 3166-1 numeric (ccn3)
+	AltSpellings []string          `json:"altSpellings,omitempty"` // Alternative spellings, e.g. "Holland" for the Netherlands
+	Region       string            `json:"region,omitempty"`       // Region, e.g. "Europe"
+	Subregion    string            `json:"subregion,omitempty"`    // Subregion, e.g. "Northern Europe"
+	Translations map[string]string `json:"-"`                      // Common name by REST Countries language code, e.g. "deu"
+}
+
+// CountryPage is a page of the country catalogue.
+type CountryPage struct {
+	Total     int              `json:"total"`     // Number of countries matching the filters
+	Page      int              `json:"page"`      // Current page, starting at 1
+	Limit     int              `json:"limit"`     // Countries per page
+	Countries []CountrySummary `json:"countries"` // Countries on this page
+}
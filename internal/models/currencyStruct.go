@@ -0,0 +1,9 @@
+package models
+
+// CurrencyInfo describes a currency supported by the exchange-rate source.
+type CurrencyInfo struct {
+	Code      string   `json:"code"`                // ISO 4217 currency code, e.g. "NOK"
+	Name      string   `json:"name,omitempty"`      // Currency name, e.g. "Norwegian krone"
+	Symbol    string   `json:"symbol,omitempty"`    // Currency symbol, e.g. "kr"
+	Countries []string `json:"countries,omitempty"` // ISO codes (cca2) of the countries using the currency
+}
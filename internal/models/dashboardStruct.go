@@ -11,10 +11,32 @@ type DashboardConfig struct {
 
 // Full dashboard response sent to the client with enriched data.
 type PopulatedDashboard struct {
-	Country       string            `json:"country"`
-	ISOCode       string            `json:"isoCode"`
-	Features      DashboardFeatures `json:"features"`
-	LastRetrieval utils.CustomTime  `json:"lastRetrieval"`
+	Country       string               `json:"country"`
+	ISOCode       string               `json:"isoCode"`
+	Features      DashboardFeatures    `json:"features"`
+	LastRetrieval utils.CustomTime     `json:"lastRetrieval"`
+	Neighbours    []PopulatedDashboard `json:"neighbours,omitempty"` // Dashboards of bordering countries, if requested
+	Region        string               `json:"region,omitempty"`     // Region of a region registration
+	Members       []PopulatedDashboard `json:"members,omitempty"`    // Dashboards of each country in a multi-country or region registration
+	Aggregates    *DashboardAggregates `json:"aggregates,omitempty"` // Aggregates over the member countries
+}
+
+// Aggregated values over the countries of a multi-country or region registration.
+type DashboardAggregates struct {
+	Countries         int                           `json:"countries"`                   // Number of member countries populated
+	TotalPopulation   int                           `json:"totalPopulation"`             // Sum of the member populations
+	TotalArea         float64                       `json:"totalArea"`                   // Sum of the member areas in km²
+	MeanTemperature   *float64                      `json:"meanTemperature,omitempty"`   // Mean of the member temperatures, if enabled
+	MeanPrecipitation *float64                      `json:"meanPrecipitation,omitempty"` // Mean of the member precipitation, if enabled
+	ExchangeRates     map[string]ExchangeRateSpread `json:"exchangeRates,omitempty"`     // Spread of the member exchange rates per target currency
+}
+
+// Spread of the exchange rates from the member currencies to a target currency.
+type ExchangeRateSpread struct {
+	Min    float64 `json:"min"`    // Lowest rate among the members
+	Max    float64 `json:"max"`    // Highest rate among the members
+	Mean   float64 `json:"mean"`   // Mean rate among the members
+	Spread float64 `json:"spread"` // Difference between the highest and lowest rate
 }
 
 // Contains detailed information shown in the dashboard.
@@ -26,6 +48,42 @@ type DashboardFeatures struct {
 	Population       int                `json:"population,omitempty"`       // Exclude if zero
 	Area             float64            `json:"area,omitempty"`             // Exclude if zero
 	TargetCurrencies map[string]float64 `json:"targetCurrencies,omitempty"` // Exclude if empty
+	Languages        map[string]string  `json:"languages,omitempty"`        // Language code to name, exclude if empty
+	Borders          []string           `json:"borders,omitempty"`          // ISO alpha-3 codes of bordering countries
+	Timezones        []string           `json:"timezones,omitempty"`        // Exclude if empty
+	Region           string             `json:"region,omitempty"`           // Exclude if empty
+	Subregion        string             `json:"subregion,omitempty"`        // Exclude if empty
+	Flag             *Flag              `json:"flag,omitempty"`             // Exclude if not requested
+	CallingCodes     []string           `json:"callingCodes,omitempty"`     // International calling codes, e.g. "+47"
+	DrivingSide      string             `json:"drivingSide,omitempty"`      // "left" or "right"
+	TopLevelDomains  []string           `json:"topLevelDomains,omitempty"`  // Exclude if empty
+
+	// Computed features
+	PopulationDensity float64    `json:"populationDensity,omitempty"` // Inhabitants per km², exclude if zero
+	LocalTime         *LocalTime `json:"localTime,omitempty"`         // Exclude if not requested
+	Daylight          *Daylight  `json:"daylight,omitempty"`          // Exclude if not requested
+}
+
+// Holds the current local time in the capital of a country.
+type LocalTime struct {
+	Time     string `json:"time"`     // RFC 3339 timestamp in the capital's timezone
+	Timezone string `json:"timezone"` // IANA timezone of the capital, e.g. "Europe/Oslo", or a fixed offset like "UTC+01:00" if unknown
+}
+
+// Holds sunrise, sunset and day length at the dashboard's weather coordinates.
+type Daylight struct {
+	Sunrise    string `json:"sunrise,omitempty"`    // Exclude during polar day or night
+	Sunset     string `json:"sunset,omitempty"`     // Exclude during polar day or night
+	DayLength  string `json:"dayLength"`            // Hours and minutes of daylight, e.g. "14h32m"
+	PolarDay   bool   `json:"polarDay,omitempty"`   // The sun does not set today
+	PolarNight bool   `json:"polarNight,omitempty"` // The sun does not rise today
+}
+
+// Holds the flag of a country as an image URL and an emoji.
+type Flag struct {
+	Emoji string `json:"emoji,omitempty"`
+	PNG   string `json:"png,omitempty"`
+	SVG   string `json:"svg,omitempty"`
 }
 
 // Holds latitude and longitude values for a country.
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// WebhookDelivery is a queued webhook delivery, stored in Firestore until it succeeds or is dead-lettered.
+type WebhookDelivery struct {
+	ID             string    `json:"id" firestore:"-"`                                      // Document ID of the delivery
+	WebhookID      string    `json:"webhookId" firestore:"webhook_id"`                      // Webhook registration the delivery is for
+	Event          string    `json:"event" firestore:"event"`                               // Event that triggered the delivery
+	Country        string    `json:"country" firestore:"country"`                           // Country the event happened for
+	Payload        string    `json:"payload" firestore:"payload"`                           // JSON body sent to the receiver
+	Status         string    `json:"status" firestore:"status"`                             // pending, delivered or dead
+	Attempts       int       `json:"attempts" firestore:"attempts"`                         // Number of attempts made so far
+	NextAttempt    time.Time `json:"nextAttempt" firestore:"next_attempt"`                  // When a pending delivery is attempted next
+	LastStatusCode int       `json:"lastStatusCode,omitempty" firestore:"last_status_code"` // Status code of the last response, if any
+	LastError      string    `json:"lastError,omitempty" firestore:"last_error,omitempty"`  // Error of the last failed attempt
+	CreatedAt      time.Time `json:"createdAt" firestore:"created_at"`                      // When the event happened
+	UpdatedAt      time.Time `json:"updatedAt" firestore:"updated_at"`                      // When the delivery last changed
+}
+
+// DeliveryAttempt is a single attempt at a webhook delivery, kept in the delivery log for troubleshooting.
+type DeliveryAttempt struct {
+	ID           string    `json:"id" firestore:"-"`                                           // Document ID of the log entry
+	DeliveryID   string    `json:"deliveryId" firestore:"delivery_id"`                         // Queued delivery the attempt belongs to
+	WebhookID    string    `json:"webhookId" firestore:"webhook_id"`                           // Webhook registration the delivery is for
+	Event        string    `json:"event" firestore:"event"`                                    // Event that triggered the delivery
+	Country      string    `json:"country" firestore:"country"`                                // Country the event happened for
+	Payload      string    `json:"payload" firestore:"payload"`                                // JSON body sent to the receiver
+	Attempt      int       `json:"attempt" firestore:"attempt"`                                // Attempt number, starting at 1
+	StatusCode   int       `json:"statusCode,omitempty" firestore:"status_code"`               // Response status code; 0 if there was no response
+	ResponseBody string    `json:"responseBody,omitempty" firestore:"response_body,omitempty"` // Start of the response body
+	LatencyMs    int64     `json:"latencyMs" firestore:"latency_ms"`                           // Time until the receiver responded
+	Success      bool      `json:"success" firestore:"success"`                                // Whether the receiver accepted the delivery
+	Error        string    `json:"error,omitempty" firestore:"error,omitempty"`                // Why the attempt failed
+	Time         time.Time `json:"time" firestore:"time"`                                      // When the attempt was made
+}
+
+// DeliveryAttemptPage is a page of a webhook's delivery log.
+type DeliveryAttemptPage struct {
+	Total    int               `json:"total"`    // Number of attempts matching the filters
+	Page     int               `json:"page"`     // Current page, starting at 1
+	Limit    int               `json:"limit"`    // Attempts per page
+	Attempts []DeliveryAttempt `json:"attempts"` // Attempts on this page, newest first
+}
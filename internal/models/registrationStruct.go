@@ -13,14 +13,36 @@ type Features struct {
 	Population       bool     `json:"population" firestore:"population"`              // Show population
 	Area             bool     `json:"area" firestore:"area"`                          // Show land area
 	TargetCurrencies []string `json:"targetCurrencies" firestore:"target_currencies"` // List of target currencies for exchange rates
+	Languages        bool     `json:"languages" firestore:"languages"`                // Show official languages
+	Borders          bool     `json:"borders" firestore:"borders"`                    // Show bordering countries
+	Timezones        bool     `json:"timezones" firestore:"timezones"`                // Show timezones
+	Region           bool     `json:"region" firestore:"region"`                      // Show region and subregion
+	Flag             bool     `json:"flag" firestore:"flag"`                          // Show flag image URL and emoji
+	CallingCode      bool     `json:"callingCode" firestore:"calling_code"`           // Show international calling code
+	DrivingSide      bool     `json:"drivingSide" firestore:"driving_side"`           // Show which side of the road cars drive on
+	TopLevelDomain   bool     `json:"topLevelDomain" firestore:"top_level_domain"`    // Show internet top-level domain
+
+	// Computed features, derived from the data fetched for the dashboard
+	PopulationDensity bool `json:"populationDensity" firestore:"population_density"` // Show inhabitants per km²
+	LocalTime         bool `json:"localTime" firestore:"local_time"`                 // Show the current local time in the capital
+	Daylight          bool `json:"daylight" firestore:"daylight"`                    // Show sunrise, sunset and day length
 }
 
 // Registration represents the configuration of a registered dashboard
 type Registration struct {
-	ID         string           `json:"id,omitempty" firestore:"id,omitempty"`        // Unique identifier for the configuration
-	Country    string           `json:"country" firestore:"country"`                  // Country name (alternatively to ISO code)
-	IsoCode    string           `json:"isoCode" firestore:"iso_code"`                 // ISO 2-letter code for the country
-	Features   Features         `json:"features" firestore:"features"`                // Features to be displayed on the dashboard
-	LastChange utils.CustomTime `json:"lastChange,omitempty" firestore:"last_change"` // Timestamp of the last change
+	ID           string           `json:"id,omitempty" firestore:"id,omitempty"`                       // Unique identifier for the configuration
+	Country      string           `json:"country" firestore:"country"`                                 // Country name (alternatively to ISO code)
+	IsoCode      string           `json:"isoCode" firestore:"iso_code"`                                // Canonical ISO 2-letter code for the country
+	IsoInput     string           `json:"isoCodeInput,omitempty" firestore:"iso_code_input,omitempty"` // ISO code as sent by the client (cca2, cca3 or ccn3)
+	Countries    []string         `json:"countries,omitempty" firestore:"countries,omitempty"`         // Countries of a multi-country registration
+	Region       string           `json:"region,omitempty" firestore:"region,omitempty"`               // Region, subregion or group of a region registration, e.g. "Nordic"
+	CountryName  string           `json:"countryName,omitempty" firestore:"-"`                         // Country name in the language of the request, if translated; country stays canonical
+	CountryNames []string         `json:"countryNames,omitempty" firestore:"-"`                        // Translated names of the countries of a multi-country registration
+	Members      []string         `json:"members,omitempty" firestore:"members,omitempty"`             // Resolved ISO codes of all countries in a multi-country or region registration
+	Neighbours   bool             `json:"neighbours" firestore:"neighbours"`                           // Also populate the features for every bordering country
+	Timezone     string           `json:"timezone,omitempty" firestore:"timezone,omitempty"`           // IANA zone timestamps are written in, e.g. "UTC". Defaults to Europe/Oslo
+	TimeFormat   string           `json:"timeFormat,omitempty" firestore:"time_format,omitempty"`      // Timestamp format, "legacy" or "rfc3339"
+	Features     Features         `json:"features" firestore:"features"`                               // Features to be displayed on the dashboard
+	LastChange   utils.CustomTime `json:"lastChange,omitempty" firestore:"last_change"`                // Timestamp of the last change
 	//URL        string           `json:"url" firestore:"url"`
 }
@@ -1,9 +1,109 @@
 package models
 
+import "time"
+
 // WebhookRegistration represents a webhook registration stored in Firestore.
 type WebhookRegistration struct {
-	ID      string `json:"id,omitempty" firestore:"id,omitempty"` // Unique identifier for the webhook registration
-	URL     string `json:"url" firestore:"url"`                   // URL to be invoked when the event occurs
-	Country string `json:"country" firestore:"country"`           // Country filter; empty means all countries
-	Event   string `json:"event" firestore:"event"`               // Event to trigger the webhook (e.g. REGISTER, CHANGE, DELETE, INVOKE)
+	ID               string            `json:"id,omitempty" firestore:"id,omitempty"`                              // Unique identifier for the webhook registration
+	URL              string            `json:"url" firestore:"url"`                                                // URL to be invoked when the event occurs
+	Country          string            `json:"country" firestore:"country"`                                        // Country filter; empty means all countries
+	Countries        []string          `json:"countries,omitempty" firestore:"countries,omitempty"`                // Country filter for several countries; empty means all countries
+	Event            string            `json:"event,omitempty" firestore:"event,omitempty"`                        // Event to trigger the webhook (e.g. REGISTER, CHANGE, DELETE, INVOKE)
+	Events           []string          `json:"events,omitempty" firestore:"events,omitempty"`                      // Events to trigger the webhook; "*" means all registration events
+	Condition        *WeatherCondition `json:"condition,omitempty" firestore:"condition,omitempty"`                // Condition of a WEATHER_ALERT subscription
+	RateCondition    *RateCondition    `json:"rateCondition,omitempty" firestore:"rate_condition,omitempty"`       // Condition of a RATE_CHANGE subscription
+	LastRateAlert    time.Time         `json:"-" firestore:"last_rate_alert,omitempty"`                            // Time of the rate the last RATE_CHANGE event was sent for
+	AlertActive      bool              `json:"alertActive,omitempty" firestore:"alert_active,omitempty"`           // Whether the condition held at the last evaluation
+	IncludeDashboard bool              `json:"includeDashboard,omitempty" firestore:"include_dashboard,omitempty"` // Include the populated dashboard in INVOKE payloads
+	InvokeEvery      int               `json:"invokeEvery,omitempty" firestore:"invoke_every,omitempty"`           // Only notify of every n-th INVOKE of the country, e.g. 50
+	Secret           string            `json:"secret,omitempty" firestore:"secret,omitempty"`                      // Key for signing deliveries; only returned when created or rotated
+	PreviousSecrets  []RetiredSecret   `json:"-" firestore:"previous_secrets,omitempty"`                           // Secrets replaced by rotations, still used for signing until they expire
+}
+
+// RetiredSecret is a webhook secret replaced by a rotation, which keeps signing deliveries during its grace period.
+type RetiredSecret struct {
+	Secret string    `firestore:"secret"`
+	Expiry time.Time `firestore:"expiry"` // End of the grace period
+}
+
+// SecretRotation is the request body for rotating a webhook secret. Both fields are optional.
+type SecretRotation struct {
+	Secret      string `json:"secret,omitempty"`      // New secret; generated if empty
+	GracePeriod string `json:"gracePeriod,omitempty"` // How long the old secret stays valid, e.g. "24h"
+}
+
+// WebhookTestResult is the outcome of a test delivery to a webhook.
+type WebhookTestResult struct {
+	DeliveryID     string `json:"deliveryId"`             // Queued delivery used for the test
+	Event          string `json:"event"`                  // Event type of the synthetic event
+	Success        bool   `json:"success"`                // Whether the receiver accepted the delivery
+	StatusCode     int    `json:"statusCode,omitempty"`   // Status code the receiver responded with; 0 if there was no response
+	LatencyMs      int64  `json:"latencyMs"`              // Time until the receiver responded
+	ResponseBody   string `json:"responseBody,omitempty"` // Start of the response body
+	Error          string `json:"error,omitempty"`        // Why the delivery failed
+	DeliveryStatus string `json:"deliveryStatus"`         // pending if the delivery will be retried, otherwise delivered or dead
+}
+
+// WebhookPayload is the JSON body of a webhook delivery.
+type WebhookPayload struct {
+	ID             string              `json:"id"`                       // ID of the webhook registration receiving the delivery
+	Country        string              `json:"country"`                  // Country code related to the event
+	Event          string              `json:"event"`                    // Event type that triggered the webhook
+	Time           string              `json:"time"`                     // Time of the event with minute precision, e.g. "20240223 06:23"
+	Timestamp      time.Time           `json:"timestamp"`                // Exact time of the event in RFC 3339
+	RegistrationID string              `json:"registrationId,omitempty"` // ID of the dashboard registration the event is about
+	Registration   *Registration       `json:"registration,omitempty"`   // Snapshot of the registration; for DELETE as it was before deletion
+	Changes        []FieldChange       `json:"changes,omitempty"`        // Changed fields of a CHANGE event
+	Dashboard      *PopulatedDashboard `json:"dashboard,omitempty"`      // Populated dashboard of an INVOKE event, if the webhook asks for it
+	Invocations    int64               `json:"invocations,omitempty"`    // Invocations of the country's dashboards so far, for INVOKE events of countries with an invokeEvery subscription
+	Alert          *WeatherAlert       `json:"alert,omitempty"`          // Condition and current value of a WEATHER_ALERT event
+	RateChange     *RateChange         `json:"rateChange,omitempty"`     // Old and new rate of a RATE_CHANGE event
+	Test           bool                `json:"test,omitempty"`           // Set for synthetic events sent by the test endpoint
+}
+
+// WeatherCondition is the condition of a WEATHER_ALERT subscription, e.g. temperature below -10.
+type WeatherCondition struct {
+	Metric    string  `json:"metric" firestore:"metric"`       // temperature (°C) or precipitation (mm)
+	Operator  string  `json:"operator" firestore:"operator"`   // below or above
+	Threshold float64 `json:"threshold" firestore:"threshold"` // Value the metric is compared with
+}
+
+// WeatherAlert tells a WEATHER_ALERT subscriber that its condition started or stopped holding.
+type WeatherAlert struct {
+	WeatherCondition
+	State string  `json:"state"` // triggered when the condition starts to hold, cleared when it stops
+	Value float64 `json:"value"` // Current value of the metric
+}
+
+// RateCondition is the condition of a RATE_CHANGE subscription, e.g. NOK/EUR moving more than 1% within an hour.
+type RateCondition struct {
+	Base      string  `json:"base" firestore:"base"`           // Base currency, e.g. NOK
+	Target    string  `json:"target" firestore:"target"`       // Target currency, e.g. EUR
+	Threshold float64 `json:"threshold" firestore:"threshold"` // Change in percent, in either direction
+	Window    string  `json:"window" firestore:"window"`       // Period the change is measured over, e.g. "1h"
+}
+
+// RateChange tells a RATE_CHANGE subscriber how much an exchange rate moved.
+type RateChange struct {
+	Base          string    `json:"base"`          // Base currency
+	Target        string    `json:"target"`        // Target currency
+	OldRate       float64   `json:"oldRate"`       // Rate at the start of the window, or at the previous event if later
+	NewRate       float64   `json:"newRate"`       // Current rate
+	ChangePercent float64   `json:"changePercent"` // Change from the old to the new rate in percent
+	Since         time.Time `json:"since"`         // When the old rate was seen
+}
+
+// WebhookEventData is what an event is about, included in the payloads of its deliveries. All fields are optional.
+type WebhookEventData struct {
+	Registration *Registration       // Registration the event is about
+	Changes      []FieldChange       // Changed fields of a CHANGE event
+	Dashboard    *PopulatedDashboard // Populated dashboard of an INVOKE event
+	Invocations  int64               // Invocation count of the country after an INVOKE event, 0 if not counted
+}
+
+// FieldChange is a registration field changed by an update.
+type FieldChange struct {
+	Field  string      `json:"field"`  // Path of the field, with nested fields joined by dots, e.g. "features.temperature"
+	Before interface{} `json:"before"` // Value before the update; null if the field was not set
+	After  interface{} `json:"after"`  // Value after the update; null if the field was removed
 }
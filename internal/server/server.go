@@ -20,10 +20,14 @@ type Server struct {
 func NewServer(port string) *Server {
 	mux := http.NewServeMux()
 	// Register endpoint handlers.
-	mux.HandleFunc(constants.Registrations, handlers.RegistrationsHandler)
-	mux.HandleFunc(constants.Dashboards, handlers.GetPopulatedDashboard)
-	mux.HandleFunc(constants.Notifications, handlers.NotificationsHandler)
+	mux.HandleFunc(constants.Registrations, handlers.Localized(handlers.RegistrationsHandler))
+	mux.HandleFunc(constants.Dashboards, handlers.Localized(handlers.GetPopulatedDashboard))
+	mux.HandleFunc(constants.Notifications, handlers.Localized(handlers.NotificationsHandler))
 	mux.HandleFunc(constants.Status, handlers.StatusHandler)
+	mux.HandleFunc(constants.Aliases, handlers.Localized(handlers.AliasesHandler))
+	mux.HandleFunc(constants.Countries, handlers.Localized(handlers.CountriesHandler))
+	mux.HandleFunc(constants.Currencies, handlers.Localized(handlers.CurrenciesHandler))
+	mux.HandleFunc(constants.Deliveries, handlers.Localized(handlers.DeliveriesHandler))
 	// Endpoint to receive webhook callbacks.
 	mux.HandleFunc("/dashboard/v1/client/", handlers.ClientReceiver)
 
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// defaultCountryAliases maps common informal names to ISO alpha-2 codes.
+// Keys are stored normalized, see normalizeCountryName.
+var defaultCountryAliases = map[string]string{
+	"uk":                       "GB",
+	"great britain":            "GB",
+	"britain":                  "GB",
+	"england":                  "GB",
+	"usa":                      "US",
+	"us":                       "US",
+	"america":                  "US",
+	"united states of america": "US",
+	"holland":                  "NL",
+	"the netherlands":          "NL",
+	"cote divoire":             "CI",
+	"ivory coast":              "CI",
+	"uae":                      "AE",
+	"emirates":                 "AE",
+	"south korea":              "KR",
+	"north korea":              "KP",
+	"russia":                   "RU",
+	"czech republic":           "CZ",
+	"burma":                    "MM",
+	"swaziland":                "SZ",
+	"macedonia":                "MK",
+	"vatican":                  "VA",
+	"drc":                      "CD",
+	"dr congo":                 "CD",
+	"east timor":               "TL",
+	"cape verde":               "CV",
+	"turkey":                   "TR",
+}
+
+// countryAliases holds the active alias dictionary. It can be extended at runtime.
+var countryAliases = struct {
+	sync.RWMutex
+	entries map[string]string
+}{entries: copyAliases(defaultCountryAliases)}
+
+// LookupCountryAlias returns the ISO alpha-2 code for a known alias.
+func LookupCountryAlias(name string) (string, bool) {
+	countryAliases.RLock()
+	defer countryAliases.RUnlock()
+	code, ok := countryAliases.entries[normalizeCountryName(name)]
+	return code, ok
+}
+
+// AddCountryAlias adds or replaces an alias for the country with the given ISO alpha-2 code.
+func AddCountryAlias(alias, isoCode string) {
+	countryAliases.Lock()
+	defer countryAliases.Unlock()
+	countryAliases.entries[normalizeCountryName(alias)] = strings.ToUpper(isoCode)
+}
+
+// RemoveCountryAlias removes an alias and reports whether it existed.
+func RemoveCountryAlias(alias string) bool {
+	countryAliases.Lock()
+	defer countryAliases.Unlock()
+	key := normalizeCountryName(alias)
+	_, ok := countryAliases.entries[key]
+	delete(countryAliases.entries, key)
+	return ok
+}
+
+// CountryAliases returns a copy of the alias dictionary.
+func CountryAliases() map[string]string {
+	countryAliases.RLock()
+	defer countryAliases.RUnlock()
+	return copyAliases(countryAliases.entries)
+}
+
+// LoadCountryAliases reads a JSON object of alias to ISO code from the given file and adds it to the dictionary.
+// Entries whose code is not a country code, or does not resolve to a country, are logged and skipped.
+func LoadCountryAliases(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var aliases map[string]string
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return err
+	}
+	for alias, code := range aliases {
+		if strings.TrimSpace(alias) == "" || !IsCountryCode(code) {
+			log.Printf("Skipping country alias %q: %q is not an ISO country code", alias, code)
+			continue
+		}
+		// Store the alpha-2 code of the country, so alpha-3 and numeric codes resolve too
+		info, err := GetCountryInfoByCode(strings.TrimSpace(code))
+		if err != nil {
+			log.Printf("Skipping country alias %q: could not resolve %q: %v", alias, code, err)
+			continue
+		}
+		AddCountryAlias(alias, info.ISOCode)
+	}
+	return nil
+}
+
+// copyAliases returns a normalized copy of an alias map.
+func copyAliases(src map[string]string) map[string]string {
+	dst := make(map[string]string, len(src))
+	for alias, code := range src {
+		dst[normalizeCountryName(alias)] = code
+	}
+	return dst
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// catalogueResponse represents an entry of the REST Countries /all response.
+type catalogueResponse []struct {
+	Name struct {
+		Common   string `json:"common"`
+		Official string `json:"official"`
+	} `json:"name"`
+	Cca2         string   `json:"cca2"`
+	Cca3         string   `json:"cca3"`
+	Ccn3         string   `json:"ccn3"`
+	AltSpellings []string `json:"altSpellings"`
+	Region       string   `json:"region"`
+	Subregion    string   `json:"subregion"`
+	Translations map[string]struct {
+		Common string `json:"common"`
+	} `json:"translations"`
+}
+
+// countryCatalogue caches the list of all countries so lookups don't hit the API every time.
+var countryCatalogue = struct {
+	sync.RWMutex
+	entries   []models.CountrySummary
+	fetchedAt time.Time
+	failedAt  time.Time // Time of the last failed refresh, cleared by a successful one
+	failure   error
+}{}
+
+// GetCountryCatalogue returns all countries known to REST Countries, served from a cache.
+// The cache is normally kept fresh by StartCatalogueRefresher, and is only fetched here
+// if it is empty or has not been refreshed within the TTL.
+// After a failed refresh the API is left alone for CatalogueRetryBackoff, so lookups don't all hit it while it is down.
+func GetCountryCatalogue() ([]models.CountrySummary, error) {
+	countryCatalogue.RLock()
+	entries, fetchedAt := countryCatalogue.entries, countryCatalogue.fetchedAt
+	failedAt, failure := countryCatalogue.failedAt, countryCatalogue.failure
+	countryCatalogue.RUnlock()
+
+	if entries != nil && time.Since(fetchedAt) < constants.CountryCatalogueTTL {
+		return entries, nil
+	}
+	if failure != nil && time.Since(failedAt) < constants.CatalogueRetryBackoff {
+		if entries != nil {
+			return entries, nil
+		}
+		return nil, failure
+	}
+	fresh, err := RefreshCountryCatalogue()
+	if err != nil && entries != nil {
+		// Serve the stale catalogue rather than failing while the API is down
+		log.Printf("Serving stale country catalogue: %v", err)
+		return entries, nil
+	}
+	return fresh, err
+}
+
+// StartCatalogueRefresher loads the country catalogue and keeps refreshing it in the background.
+func StartCatalogueRefresher(interval time.Duration) {
+	for {
+		if _, err := RefreshCountryCatalogue(); err != nil {
+			log.Printf("Failed to refresh country catalogue: %v", err)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// RefreshCountryCatalogue fetches the full country list from REST Countries and replaces the cache.
+// A failure is recorded so GetCountryCatalogue can back off.
+func RefreshCountryCatalogue() ([]models.CountrySummary, error) {
+	entries, err := fetchCountryCatalogue()
+
+	countryCatalogue.Lock()
+	defer countryCatalogue.Unlock()
+	if err != nil {
+		countryCatalogue.failedAt, countryCatalogue.failure = time.Now(), err
+		return nil, err
+	}
+	countryCatalogue.entries = entries
+	countryCatalogue.fetchedAt = time.Now()
+	countryCatalogue.failedAt, countryCatalogue.failure = time.Time{}, nil
+	return entries, nil
+}
+
+// fetchCountryCatalogue requests the full country list from REST Countries, sorted by name.
+func fetchCountryCatalogue() ([]models.CountrySummary, error) {
+	url := fmt.Sprintf("%s/all?fields=name,cca2,cca3,ccn3,altSpellings,region,subregion,translations", constants.RestCountriesAPI)
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrCountryNotFound
+	}
+
+	var data catalogueResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	entries := make([]models.CountrySummary, 0, len(data))
+	for _, c := range data {
+		if c.Cca2 == "" || c.Name.Common == "" {
+			continue
+		}
+		translations := make(map[string]string, len(c.Translations))
+		for lang, t := range c.Translations {
+			translations[lang] = t.Common
+		}
+		entries = append(entries, models.CountrySummary{
+			Name:         c.Name.Common,
+			OfficialName: c.Name.Official,
+			ISOCode:      c.Cca2,
+			ISOAlpha3:    c.Cca3,
+			ISONumeric:   c.Ccn3,
+			AltSpellings: c.AltSpellings,
+			Region:       c.Region,
+			Subregion:    c.Subregion,
+			Translations: translations,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// countryGroups are well-known groups of countries that are not regions in REST Countries.
+// Keys are stored normalized, see normalizeCountryName.
+var countryGroups = map[string][]string{
+	"nordic":      {"DK", "FI", "IS", "NO", "SE"},
+	"nordics":     {"DK", "FI", "IS", "NO", "SE"},
+	"scandinavia": {"DK", "NO", "SE"},
+	"baltic":      {"EE", "LV", "LT"},
+	"baltics":     {"EE", "LV", "LT"},
+	"benelux":     {"BE", "NL", "LU"},
+	"dach":        {"AT", "CH", "DE"},
+}
+
+// ErrRegionNotFound is returned when a region, subregion or group has no member countries.
+var ErrRegionNotFound = errors.New("region not found")
+
+// GetRegionMembers returns the countries of a region ("Europe"), subregion ("Western Africa")
+// or well-known group ("Nordic"), ordered by name.
+func GetRegionMembers(region string) ([]models.CountrySummary, error) {
+	catalogue, err := GetCountryCatalogue()
+	if err != nil {
+		return nil, err
+	}
+
+	needle := normalizeCountryName(region)
+	group := make(map[string]bool)
+	for _, code := range countryGroups[needle] {
+		group[code] = true
+	}
+
+	var members []models.CountrySummary
+	for _, c := range catalogue {
+		if group[c.ISOCode] || normalizeCountryName(c.Region) == needle || normalizeCountryName(c.Subregion) == needle {
+			members = append(members, c)
+		}
+	}
+	if len(members) == 0 {
+		return nil, ErrRegionNotFound
+	}
+	return members, nil
+}
+
+// FindCountry looks up a country in the catalogue by name, official name or alternative spelling.
+// Matching ignores case, accents and punctuation, so "Cote d'Ivoire" finds "Côte d'Ivoire".
+func FindCountry(query string) (*models.CountrySummary, bool) {
+	catalogue, err := GetCountryCatalogue()
+	if err != nil {
+		return nil, false
+	}
+
+	needle := normalizeCountryName(query)
+	if needle == "" {
+		return nil, false
+	}
+	for i := range catalogue {
+		for _, name := range countryNames(&catalogue[i]) {
+			if normalizeCountryName(name) == needle {
+				return &catalogue[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// SuggestCountries returns up to limit countries whose names start with or closely resemble the query.
+// Prefix matches come first, then fuzzy matches ordered by edit distance.
+func SuggestCountries(query string, limit int) []models.CountrySummary {
+	catalogue, err := GetCountryCatalogue()
+	if err != nil {
+		return nil
+	}
+
+	needle := normalizeCountryName(query)
+	if needle == "" {
+		return nil
+	}
+
+	type scored struct {
+		country models.CountrySummary
+		score   int
+	}
+	var matches []scored
+	for _, c := range catalogue {
+		best := -1
+		for _, name := range countryNames(&c) {
+			candidate := normalizeCountryName(name)
+			score := -1
+			if strings.HasPrefix(candidate, needle) {
+				score = 0
+			} else if d := editDistance(needle, candidate); d <= maxSuggestionDistance(needle) {
+				score = d
+			}
+			if score >= 0 && (best < 0 || score < best) {
+				best = score
+			}
+		}
+		if best >= 0 {
+			matches = append(matches, scored{c, best})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score < matches[j].score })
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	suggestions := make([]models.CountrySummary, 0, len(matches))
+	for _, m := range matches {
+		suggestions = append(suggestions, m.country)
+	}
+	return suggestions
+}
+
+// countryNames returns all names a catalogue entry can be matched on.
+func countryNames(c *models.CountrySummary) []string {
+	names := []string{c.Name, c.OfficialName}
+	return append(names, c.AltSpellings...)
+}
+
+// maxSuggestionDistance returns how many edits a fuzzy match may be away from the query.
+func maxSuggestionDistance(query string) int {
+	n := len([]rune(query))
+	switch {
+	case n <= 3:
+		return 0
+	case n <= 6:
+		return 1
+	case n <= 10:
+		return 2
+	}
+	return 3
+}
+
+// diacritics maps accented letters to their plain counterparts for name matching.
+var diacritics = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a", "ā", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o", "ø", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n", "ý", "y", "æ", "ae", "ß", "ss",
+)
+
+// normalizeCountryName lowercases a name and strips accents and punctuation.
+func normalizeCountryName(name string) string {
+	name = diacritics.Replace(strings.ToLower(strings.TrimSpace(name)))
+	var b strings.Builder
+	space := false
+	for _, r := range name {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r > 127:
+			b.WriteRune(r)
+			space = false
+		case r == ' ' || r == '-':
+			if !space && b.Len() > 0 {
+				b.WriteRune(' ')
+				space = true
+			}
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// editDistance returns the edit distance between two strings, counting a swap of adjacent letters as one edit.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
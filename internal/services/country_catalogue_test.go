@@ -0,0 +1,34 @@
+package services
+
+import "testing"
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"norway", "norway", 0},
+		{"", "chad", 4},
+		{"netherlnds", "netherlands", 1}, // Missing letter
+		{"swedenn", "sweden", 1},         // Extra letter
+		{"frence", "france", 1},          // Wrong letter
+		{"nroway", "norway", 1},          // Swapped neighbours count once
+		{"iceland", "ireland", 1},        // Close names of different countries
+		{"åland", "aland", 1},            // Runes, not bytes
+		{"türkiye", "turkiye", 1},        // Runes, not bytes
+		{"peru", "chad", 4},              // Nothing in common
+		{"kitten", "sitting", 3},         // Classic example
+		{"ca", "abc", 3},                 // Transposition without editing the swapped letters again
+		{"germany", "gremany", 1},        // Swap inside a word
+		{"united kingdom", "united kingdon", 1},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := editDistance(tt.b, tt.a); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
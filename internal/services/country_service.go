@@ -11,14 +11,17 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
-// restCountryResponse represents the structure of the REST Countries API response.
-type restCountryResponse []struct {
+// restCountry represents a single country in the REST Countries API response.
+type restCountry struct {
 	Name struct {
 		Common string `json:"common"`
 	} `json:"name"`
 	Cca2       string    `json:"cca2"`
+	Cca3       string    `json:"cca3"`
+	Ccn3       string    `json:"ccn3"`
 	Capital    []string  `json:"capital"`
 	Latlng     []float64 `json:"latlng"`
 	Population int       `json:"population"`
@@ -26,15 +29,112 @@ type restCountryResponse []struct {
 	Currencies map[string]struct {
 		Name string `json:"name"`
 	} `json:"currencies"`
+	Languages map[string]string `json:"languages"`
+	Borders   []string          `json:"borders"`
+	Timezones []string          `json:"timezones"`
+	Region    string            `json:"region"`
+	Subregion string            `json:"subregion"`
+	Flag      string            `json:"flag"`
+	Flags     struct {
+		PNG string `json:"png"`
+		SVG string `json:"svg"`
+	} `json:"flags"`
+	Idd struct {
+		Root     string   `json:"root"`
+		Suffixes []string `json:"suffixes"`
+	} `json:"idd"`
+	Car struct {
+		Side string `json:"side"`
+	} `json:"car"`
+	Tld         []string `json:"tld"`
+	CapitalInfo struct {
+		Latlng []float64 `json:"latlng"`
+	} `json:"capitalInfo"`
 }
 
+// restCountryResponse represents the structure of the REST Countries API response.
+type restCountryResponse []restCountry
+
 // ErrCountryNotFound is returned when the country is not found in the API.
 var ErrCountryNotFound = errors.New("country not found")
 
 // GetCountryInfo fetches and returns country info for the given country name.
 func GetCountryInfo(countryName string) (*models.CountryInfo, error) {
 	url := fmt.Sprintf("%s/name/%s", constants.RestCountriesAPI, countryName)
+	return fetchCountryInfo(url)
+}
+
+// GetCountryInfoByCode fetches and returns country info for an ISO 3166-1 code.
+// The code can be alpha-2 (NO), alpha-3 (NOR) or numeric (578).
+// The data is fetched on every call, as dashboards show it; codes are compared with SameCountry instead.
+func GetCountryInfoByCode(code string) (*models.CountryInfo, error) {
+	key := strings.ToUpper(strings.TrimSpace(code))
+	url := fmt.Sprintf("%s/alpha/%s", constants.RestCountriesAPI, key)
+	return fetchCountryInfo(url)
+}
+
+// IsCountryCode reports whether the value has the shape of an alpha-2, alpha-3 or numeric ISO code.
+func IsCountryCode(value string) bool {
+	value = strings.TrimSpace(value)
+	switch len(value) {
+	case 2:
+		return isLetters(value)
+	case 3:
+		return isLetters(value) || isDigits(value)
+	}
+	return false
+}
+
+// CountryCodeMatches reports whether code is any of the equivalent ISO codes of the given country.
+func CountryCodeMatches(info *models.CountryInfo, code string) bool {
+	if info == nil || code == "" {
+		return false
+	}
+	for _, c := range []string{info.ISOCode, info.ISOAlpha3, info.ISONumeric} {
+		if c != "" && strings.EqualFold(c, code) {
+			return true
+		}
+	}
+	return false
+}
 
+// SameCountry reports whether two ISO codes, in any of the supported forms, refer to the same country.
+// The codes are looked up in the country catalogue, so repeated webhook filter checks don't hit the API.
+func SameCountry(a, b string) bool {
+	if strings.EqualFold(a, b) {
+		return true
+	}
+	if !IsCountryCode(a) || !IsCountryCode(b) {
+		return false
+	}
+	// Different alpha-2 codes are different countries, no lookup needed
+	if len(a) == 2 && len(b) == 2 {
+		return false
+	}
+	catalogue, err := GetCountryCatalogue()
+	if err != nil {
+		return false
+	}
+	for i := range catalogue {
+		if summaryHasCode(&catalogue[i], a) {
+			return summaryHasCode(&catalogue[i], b)
+		}
+	}
+	return false
+}
+
+// summaryHasCode reports whether code is any of the equivalent ISO codes of a catalogue entry.
+func summaryHasCode(c *models.CountrySummary, code string) bool {
+	for _, own := range []string{c.ISOCode, c.ISOAlpha3, c.ISONumeric} {
+		if own != "" && strings.EqualFold(own, strings.TrimSpace(code)) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchCountryInfo requests the given REST Countries URL and maps the first result.
+func fetchCountryInfo(url string) (*models.CountryInfo, error) {
 	resp, err := http.Get(url)
 	if err != nil {
 		return nil, err
@@ -54,8 +154,11 @@ func GetCountryInfo(countryName string) (*models.CountryInfo, error) {
 		return nil, ErrCountryNotFound
 	}
 
-	c := data[0]
+	return toCountryInfo(data[0]), nil
+}
 
+// toCountryInfo maps a REST Countries entry to the model used by the dashboard.
+func toCountryInfo(c restCountry) *models.CountryInfo {
 	// Extract currency code
 	var baseCurrency string
 	for code := range c.Currencies {
@@ -76,14 +179,73 @@ func GetCountryInfo(countryName string) (*models.CountryInfo, error) {
 		lon = c.Latlng[1]
 	}
 
+	// Extract capital coordinates, falling back to the country's
+	capitalLat, capitalLon := lat, lon
+	if len(c.CapitalInfo.Latlng) >= 2 {
+		capitalLat = c.CapitalInfo.Latlng[0]
+		capitalLon = c.CapitalInfo.Latlng[1]
+	}
+
 	return &models.CountryInfo{
-		Name:       c.Name.Common,
-		ISOCode:    c.Cca2,
-		Capital:    capital,
-		Latitude:   lat,
-		Longitude:  lon,
-		Population: c.Population,
-		Area:       c.Area,
-		Currency:   baseCurrency,
-	}, nil
+		Name:            c.Name.Common,
+		ISOCode:         c.Cca2,
+		ISOAlpha3:       c.Cca3,
+		ISONumeric:      c.Ccn3,
+		Capital:         capital,
+		CapitalLat:      capitalLat,
+		CapitalLon:      capitalLon,
+		Latitude:        lat,
+		Longitude:       lon,
+		Population:      c.Population,
+		Area:            c.Area,
+		Currency:        baseCurrency,
+		Languages:       c.Languages,
+		Borders:         c.Borders,
+		Timezones:       c.Timezones,
+		Region:          c.Region,
+		Subregion:       c.Subregion,
+		FlagEmoji:       c.Flag,
+		FlagPNG:         c.Flags.PNG,
+		FlagSVG:         c.Flags.SVG,
+		CallingCodes:    callingCodes(c.Idd.Root, c.Idd.Suffixes),
+		DrivingSide:     c.Car.Side,
+		TopLevelDomains: c.Tld,
+	}
+}
+
+// callingCodes combines the IDD root and suffixes into full calling codes, one per suffix (e.g. +3906698 and +379 for the Vatican).
+// In the shared numbering plans of +1 and +7 the suffixes are area codes, so countries with several of them
+// (e.g. the US or Russia) are reduced to the root only.
+func callingCodes(root string, suffixes []string) []string {
+	if root == "" {
+		return nil
+	}
+	if len(suffixes) == 0 || (len(suffixes) > 1 && (root == "+1" || root == "+7")) {
+		return []string{root}
+	}
+	codes := make([]string, 0, len(suffixes))
+	for _, suffix := range suffixes {
+		codes = append(codes, root+suffix)
+	}
+	return codes
+}
+
+// isLetters reports whether s only contains ASCII letters.
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// isDigits reports whether s only contains ASCII digits.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"Country-Dashboard-Service/internal/models"
+	"testing"
+	"time"
+)
+
+// useTestCatalogue serves the given countries from the catalogue cache for the rest of the test.
+func useTestCatalogue(t *testing.T, entries []models.CountrySummary) {
+	t.Helper()
+
+	countryCatalogue.Lock()
+	old := countryCatalogue.entries
+	oldFetchedAt := countryCatalogue.fetchedAt
+	countryCatalogue.entries, countryCatalogue.fetchedAt = entries, time.Now()
+	countryCatalogue.Unlock()
+
+	t.Cleanup(func() {
+		countryCatalogue.Lock()
+		countryCatalogue.entries, countryCatalogue.fetchedAt = old, oldFetchedAt
+		countryCatalogue.Unlock()
+	})
+}
+
+// nordicCatalogue is a small catalogue with the equivalent ISO codes of each country.
+var nordicCatalogue = []models.CountrySummary{
+	{Name: "Norway", ISOCode: "NO", ISOAlpha3: "NOR", ISONumeric: "578"},
+	{Name: "Sweden", ISOCode: "SE", ISOAlpha3: "SWE", ISONumeric: "752"},
+	{Name: "Finland", ISOCode: "FI", ISOAlpha3: "FIN", ISONumeric: "246"},
+}
+
+func TestSameCountry(t *testing.T) {
+	useTestCatalogue(t, nordicCatalogue)
+
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"NO", "NO", true},
+		{"no", "NO", true},   // Case-insensitive
+		{"NO", "NOR", true},  // Alpha-2 and alpha-3
+		{"nor", "578", true}, // Alpha-3 and numeric
+		{"578", "NO", true},  // Numeric and alpha-2
+		{"NO", "SE", false},  // Different alpha-2 codes
+		{"NOR", "SWE", false},
+		{"578", "752", false},
+		{"NO", "FIN", false},
+		{"XX", "XXX", false},    // Unknown to the catalogue
+		{"Norway", "NO", false}, // Names are not codes
+		{"", "NO", false},
+	}
+	for _, tt := range tests {
+		if got := SameCountry(tt.a, tt.b); got != tt.want {
+			t.Errorf("SameCountry(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := SameCountry(tt.b, tt.a); got != tt.want {
+			t.Errorf("SameCountry(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+// translationLanguages maps language subtags from Accept-Language to the
+// language codes REST Countries uses for its translations.
+var translationLanguages = map[string]string{
+	"ar": "ara",
+	"br": "bre",
+	"cs": "ces",
+	"cy": "cym",
+	"de": "deu",
+	"et": "est",
+	"fi": "fin",
+	"fr": "fra",
+	"hr": "hrv",
+	"hu": "hun",
+	"it": "ita",
+	"ja": "jpn",
+	"ko": "kor",
+	"nl": "nld",
+	"fa": "per",
+	"pl": "pol",
+	"pt": "por",
+	"ru": "rus",
+	"sk": "slk",
+	"es": "spa",
+	"sr": "srp",
+	"sv": "swe",
+	"tr": "tur",
+	"ur": "urd",
+	"zh": "zho",
+}
+
+// LocalizedCountryName returns the name of the country in the first of the preferred languages
+// REST Countries has a translation for. English is the fallback, in which case name is returned as is.
+func LocalizedCountryName(name string, languages []string) string {
+	if name == "" || len(languages) == 0 {
+		return name
+	}
+
+	for _, lang := range languages {
+		if lang == "en" {
+			return name
+		}
+		key, ok := translationLanguages[lang]
+		if !ok {
+			continue
+		}
+		country, found := FindCountry(name)
+		if !found {
+			return name
+		}
+		if translated := country.Translations[key]; translated != "" {
+			return translated
+		}
+	}
+	return name
+}
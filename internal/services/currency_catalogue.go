@@ -0,0 +1,189 @@
+package services
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// currencyCountriesResponse represents an entry of the REST Countries /all?fields=cca2,currencies response.
+type currencyCountriesResponse []struct {
+	Cca2       string `json:"cca2"`
+	Currencies map[string]struct {
+		Name   string `json:"name"`
+		Symbol string `json:"symbol"`
+	} `json:"currencies"`
+}
+
+// currencyCatalogue caches the supported currencies, keyed by currency code.
+var currencyCatalogue = struct {
+	sync.RWMutex
+	entries   map[string]models.CurrencyInfo
+	fetchedAt time.Time
+	failedAt  time.Time // Time of the last failed refresh, cleared by a successful one
+	failure   error
+}{}
+
+// GetCurrencyCatalogue returns the currencies supported by the exchange-rate source, ordered by code.
+// Like the country catalogue, a stale copy is served if a refresh fails.
+func GetCurrencyCatalogue() ([]models.CurrencyInfo, error) {
+	entries, err := currencyEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]models.CurrencyInfo, 0, len(entries))
+	for _, c := range entries {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list, nil
+}
+
+// GetCurrency returns a single supported currency.
+func GetCurrency(code string) (models.CurrencyInfo, bool, error) {
+	entries, err := currencyEntries()
+	if err != nil {
+		return models.CurrencyInfo{}, false, err
+	}
+	c, ok := entries[strings.ToUpper(code)]
+	return c, ok, nil
+}
+
+// UnsupportedCurrencies returns the codes the exchange-rate source does not support.
+func UnsupportedCurrencies(codes []string) ([]string, error) {
+	entries, err := currencyEntries()
+	if err != nil {
+		return nil, err
+	}
+	var unsupported []string
+	for _, code := range codes {
+		if _, ok := entries[strings.ToUpper(code)]; !ok {
+			unsupported = append(unsupported, code)
+		}
+	}
+	return unsupported, nil
+}
+
+// currencyEntries returns the cached currency map, refreshing it if it is empty or expired.
+// Like the country catalogue, it backs off for CatalogueRetryBackoff after a failed refresh.
+func currencyEntries() (map[string]models.CurrencyInfo, error) {
+	currencyCatalogue.RLock()
+	entries, fetchedAt := currencyCatalogue.entries, currencyCatalogue.fetchedAt
+	failedAt, failure := currencyCatalogue.failedAt, currencyCatalogue.failure
+	currencyCatalogue.RUnlock()
+
+	if entries != nil && time.Since(fetchedAt) < constants.CountryCatalogueTTL {
+		return entries, nil
+	}
+	if failure != nil && time.Since(failedAt) < constants.CatalogueRetryBackoff {
+		if entries != nil {
+			return entries, nil
+		}
+		return nil, failure
+	}
+	fresh, err := RefreshCurrencyCatalogue()
+	if err != nil && entries != nil {
+		log.Printf("Serving stale currency catalogue: %v", err)
+		return entries, nil
+	}
+	return fresh, err
+}
+
+// RefreshCurrencyCatalogue rebuilds the currency cache. The supported codes come from the
+// currency API, and names, symbols and countries from REST Countries' currencies objects.
+func RefreshCurrencyCatalogue() (map[string]models.CurrencyInfo, error) {
+	rates, err := fetchSupportedCurrencies(constants.CurrencyCatalogueBase)
+	if err != nil {
+		currencyCatalogue.Lock()
+		currencyCatalogue.failedAt, currencyCatalogue.failure = time.Now(), err
+		currencyCatalogue.Unlock()
+		return nil, err
+	}
+
+	entries := make(map[string]models.CurrencyInfo, len(rates))
+	for code := range rates {
+		entries[code] = models.CurrencyInfo{Code: code}
+	}
+
+	// Currency metadata is nice to have, so the codes alone are used if REST Countries fails
+	if metadata, err := fetchCurrencyCountries(); err != nil {
+		log.Printf("Failed to fetch currency metadata: %v", err)
+	} else {
+		for _, country := range metadata {
+			for code, meta := range country.Currencies {
+				entry, ok := entries[code]
+				if !ok {
+					continue
+				}
+				if entry.Name == "" {
+					entry.Name, entry.Symbol = meta.Name, meta.Symbol
+				}
+				entry.Countries = append(entry.Countries, country.Cca2)
+				entries[code] = entry
+			}
+		}
+		for code, entry := range entries {
+			sort.Strings(entry.Countries)
+			entries[code] = entry
+		}
+	}
+
+	currencyCatalogue.Lock()
+	currencyCatalogue.entries = entries
+	currencyCatalogue.fetchedAt = time.Now()
+	currencyCatalogue.failedAt, currencyCatalogue.failure = time.Time{}, nil
+	currencyCatalogue.Unlock()
+	return entries, nil
+}
+
+// fetchSupportedCurrencies returns all rates the currency API has for the base currency, including the base itself.
+func fetchSupportedCurrencies(base string) (map[string]float64, error) {
+	resp, err := http.Get(constants.CurrencyAPI + base)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrCurrencyDataUnavailable
+	}
+
+	var data currencyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	if data.Rates == nil {
+		data.Rates = make(map[string]float64)
+	}
+	data.Rates[base] = 1
+	return data.Rates, nil
+}
+
+// fetchCurrencyCountries fetches the currencies used by every country from REST Countries.
+func fetchCurrencyCountries() (currencyCountriesResponse, error) {
+	url := fmt.Sprintf("%s/all?fields=cca2,currencies", constants.RestCountriesAPI)
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrCountryNotFound
+	}
+
+	var data currencyCountriesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/firestore"
+	"Country-Dashboard-Service/internal/models"
+	"log"
+	"os"
+	"time"
+)
+
+// logDeliveryAttempt records an attempt at a delivery in the delivery log. Failing to log never fails the delivery.
+func logDeliveryAttempt(delivery *models.WebhookDelivery, resp webhookResponse, latency time.Duration, err error) *models.DeliveryAttempt {
+	attempt := models.DeliveryAttempt{
+		DeliveryID:   delivery.ID,
+		WebhookID:    delivery.WebhookID,
+		Event:        delivery.Event,
+		Country:      delivery.Country,
+		Payload:      delivery.Payload,
+		Attempt:      delivery.Attempts,
+		StatusCode:   resp.statusCode,
+		ResponseBody: resp.body,
+		LatencyMs:    latency.Milliseconds(),
+		Success:      err == nil,
+		Time:         time.Now(),
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	if err := firestore.AddDeliveryAttempt(&attempt); err != nil {
+		log.Printf("Could not log attempt %d of webhook delivery %s: %v", attempt.Attempt, delivery.ID, err)
+	}
+	return &attempt
+}
+
+// DeliveryLogRetention returns how long delivery attempts are kept, set by DELIVERY_LOG_RETENTION or 30 days.
+func DeliveryLogRetention() time.Duration {
+	if value := os.Getenv(constants.EnvDeliveryLogRetention); value != "" {
+		retention, err := time.ParseDuration(value)
+		if err == nil && retention > 0 {
+			return retention
+		}
+		log.Printf("Ignoring invalid %s %q", constants.EnvDeliveryLogRetention, value)
+	}
+	return constants.DeliveryLogRetention
+}
+
+// StartDeliveryLogPruner deletes expired delivery attempts at the given interval. It is meant to run in its own goroutine.
+func StartDeliveryLogPruner(interval time.Duration) {
+	for {
+		retention := DeliveryLogRetention()
+		deleted, err := firestore.PruneDeliveryAttempts(time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("Failed to prune the webhook delivery log: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Pruned %d webhook delivery attempts older than %s", deleted, retention)
+		}
+		time.Sleep(interval)
+	}
+}
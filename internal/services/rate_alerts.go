@@ -0,0 +1,150 @@
+package services
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/firestore"
+	"Country-Dashboard-Service/internal/models"
+	"log"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// rateSample is an exchange rate seen by the rate monitor.
+type rateSample struct {
+	at   time.Time
+	rate float64
+}
+
+// rateHistory keeps the rates seen by the monitor per currency pair, for as long as the longest window allowed.
+var rateHistory = struct {
+	sync.Mutex
+	samples map[string][]rateSample
+}{samples: make(map[string][]rateSample)}
+
+// StartRateMonitor polls the exchange rates of the RATE_CHANGE subscriptions at the given interval.
+// It is meant to run in its own goroutine.
+func StartRateMonitor(interval time.Duration) {
+	for {
+		MonitorExchangeRates()
+		time.Sleep(interval)
+	}
+}
+
+/*
+MonitorExchangeRates fetches the current rates of all RATE_CHANGE subscriptions and notifies the subscribers
+whose rate moved more than their threshold within their window. The baseline is the oldest rate seen within the
+window, or the rate of the previous event if that is later, so a single move is only notified once.
+*/
+func MonitorExchangeRates() {
+	entries, err := firestore.WebhooksForEvent(constants.EventRateChange)
+	if err != nil {
+		log.Printf("Could not look up exchange-rate subscriptions: %v", err)
+		return
+	}
+
+	// One request per base currency for all of its targets
+	var subscriptions []models.WebhookRegistration
+	targets := make(map[string][]string)
+	for _, entry := range entries {
+		// Wildcard subscriptions only cover registration events
+		if entry.RateCondition == nil || !slices.Contains(webhookEvents(entry), constants.EventRateChange) {
+			continue
+		}
+		subscriptions = append(subscriptions, entry)
+		base, target := entry.RateCondition.Base, entry.RateCondition.Target
+		if !slices.Contains(targets[base], target) {
+			targets[base] = append(targets[base], target)
+		}
+	}
+
+	now := time.Now()
+	for base, codes := range targets {
+		rates, err := GetExchangeRates(base, codes)
+		if err != nil {
+			log.Printf("Could not get exchange rates for %s: %v", base, err)
+			continue
+		}
+		for target, rate := range rates {
+			recordRate(base+"/"+target, now, rate)
+		}
+	}
+
+	for _, entry := range subscriptions {
+		checkRateChange(entry, now)
+	}
+}
+
+// recordRate adds a rate to the history of a currency pair and drops the rates older than any window.
+func recordRate(pair string, at time.Time, rate float64) {
+	rateHistory.Lock()
+	defer rateHistory.Unlock()
+
+	samples := append(rateHistory.samples[pair], rateSample{at: at, rate: rate})
+	cutoff := at.Add(-constants.MaxRateWindow)
+	for len(samples) > 0 && samples[0].at.Before(cutoff) {
+		samples = samples[1:]
+	}
+	rateHistory.samples[pair] = samples
+}
+
+// checkRateChange notifies a subscriber if the rate seen at now moved more than its threshold from the baseline.
+func checkRateChange(entry models.WebhookRegistration, now time.Time) {
+	condition := entry.RateCondition
+	window, err := time.ParseDuration(condition.Window)
+	if err != nil {
+		return
+	}
+	since := now.Add(-window)
+	if entry.LastRateAlert.After(since) {
+		since = entry.LastRateAlert
+	}
+
+	rateHistory.Lock()
+	samples := rateHistory.samples[condition.Base+"/"+condition.Target]
+	var baseline, current rateSample
+	for _, sample := range samples {
+		if baseline.at.IsZero() && !sample.at.Before(since) {
+			baseline = sample
+		}
+		if sample.at.Equal(now) {
+			current = sample
+		}
+	}
+	rateHistory.Unlock()
+	if baseline.at.IsZero() || current.at.IsZero() || baseline.at.Equal(current.at) || baseline.rate == 0 {
+		return
+	}
+
+	change := (current.rate - baseline.rate) / baseline.rate * 100
+	if math.Abs(change) < condition.Threshold {
+		return
+	}
+	// Another instance may have sent the event already
+	changed, err := firestore.SetWebhookRateAlert(entry.ID, entry.LastRateAlert, now)
+	if err != nil || !changed {
+		if err != nil {
+			log.Printf("Could not update exchange-rate alert %s: %v", entry.ID, err)
+		}
+		return
+	}
+
+	payload := models.WebhookPayload{
+		ID:        entry.ID,
+		Event:     constants.EventRateChange,
+		Time:      now.Format("20060102 15:04"),
+		Timestamp: now,
+		RateChange: &models.RateChange{
+			Base:          condition.Base,
+			Target:        condition.Target,
+			OldRate:       baseline.rate,
+			NewRate:       current.rate,
+			ChangePercent: math.Round(change*1000) / 1000,
+			Since:         baseline.at,
+		},
+	}
+	if err := enqueueDelivery(entry.ID, constants.EventRateChange, "", payload); err != nil {
+		log.Printf("Could not queue exchange-rate alert %s: %v", entry.ID, err)
+	}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/firestore"
+	"Country-Dashboard-Service/internal/models"
+	"log"
+	"slices"
+	"time"
+)
+
+// StartWeatherAlertEvaluator evaluates the weather alert subscriptions at the given interval.
+// It is meant to run in its own goroutine.
+func StartWeatherAlertEvaluator(interval time.Duration) {
+	for {
+		EvaluateWeatherAlerts()
+		time.Sleep(interval)
+	}
+}
+
+/*
+EvaluateWeatherAlerts checks the condition of every WEATHER_ALERT subscription against the current weather
+in its country and notifies the subscriber when the condition starts or stops holding.
+A condition that keeps holding is not notified again until it has cleared.
+*/
+func EvaluateWeatherAlerts() {
+	entries, err := firestore.WebhooksForEvent(constants.EventWeatherAlert)
+	if err != nil {
+		log.Printf("Could not look up weather alert subscriptions: %v", err)
+		return
+	}
+
+	// Subscriptions for the same country share one weather lookup
+	weather := make(map[string][2]float64)
+	for _, entry := range entries {
+		// Wildcard subscriptions only cover registration events
+		if entry.Condition == nil || !slices.Contains(webhookEvents(entry), constants.EventWeatherAlert) {
+			continue
+		}
+		countries := webhookCountries(entry)
+		if len(countries) == 0 {
+			continue
+		}
+		country := countries[0]
+
+		current, ok := weather[country]
+		if !ok {
+			temp, precip, err := countryWeather(country)
+			if err != nil {
+				log.Printf("Could not get weather for %s: %v", country, err)
+				continue
+			}
+			current = [2]float64{temp, precip}
+			weather[country] = current
+		}
+
+		value := current[0]
+		if entry.Condition.Metric == "precipitation" {
+			value = current[1]
+		}
+		holds := conditionHolds(*entry.Condition, value)
+		if holds == entry.AlertActive {
+			continue
+		}
+		// Another instance may have seen the change first
+		changed, err := firestore.SetWebhookAlertActive(entry.ID, holds)
+		if err != nil || !changed {
+			if err != nil {
+				log.Printf("Could not update weather alert %s: %v", entry.ID, err)
+			}
+			continue
+		}
+
+		state := constants.AlertCleared
+		if holds {
+			state = constants.AlertTriggered
+		}
+		now := time.Now()
+		payload := models.WebhookPayload{
+			ID:        entry.ID,
+			Country:   country,
+			Event:     constants.EventWeatherAlert,
+			Time:      now.Format("20060102 15:04"),
+			Timestamp: now,
+			Alert:     &models.WeatherAlert{WeatherCondition: *entry.Condition, State: state, Value: value},
+		}
+		if err := enqueueDelivery(entry.ID, constants.EventWeatherAlert, country, payload); err != nil {
+			log.Printf("Could not queue weather alert %s: %v", entry.ID, err)
+		}
+	}
+}
+
+// conditionHolds reports whether the value satisfies the weather condition.
+func conditionHolds(condition models.WeatherCondition, value float64) bool {
+	if condition.Operator == constants.ConditionBelow {
+		return value < condition.Threshold
+	}
+	return value > condition.Threshold
+}
+
+// countryWeather returns the average temperature and precipitation at the coordinates of a country.
+func countryWeather(code string) (float64, float64, error) {
+	info, err := GetCountryInfoByCode(code)
+	if err != nil {
+		return 0, 0, err
+	}
+	return GetWeatherData(info.Latitude, info.Longitude)
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/constants/errorMessages"
+	"Country-Dashboard-Service/internal/firestore"
+	"Country-Dashboard-Service/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrDeliveryNotDead = errors.New(errorMessages.DeliveryNotDead)
+	ErrWebhookNotFound = errors.New(errorMessages.WebhookNotFound)
+)
+
+// enqueueDelivery stores a delivery of the payload to the given webhook and makes the first attempt right away.
+func enqueueDelivery(webhookID, event, country string, payload models.WebhookPayload) error {
+	id, err := queueDelivery(webhookID, event, country, payload)
+	if err != nil {
+		return err
+	}
+
+	go AttemptDelivery(id)
+	return nil
+}
+
+// queueDelivery stores a pending delivery of the payload to the given webhook and returns its ID.
+func queueDelivery(webhookID, event, country string, payload models.WebhookPayload) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf(errorMessages.WebhookPayloadMarshallingError, err)
+		return "", err
+	}
+
+	now := time.Now()
+	delivery := models.WebhookDelivery{
+		WebhookID:   webhookID,
+		Event:       event,
+		Country:     country,
+		Payload:     string(data),
+		Status:      constants.DeliveryPending,
+		NextAttempt: now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	return firestore.AddDelivery(&delivery)
+}
+
+/*
+AttemptDelivery sends a queued delivery if it is due.
+The webhook is looked up again so the current URL and secrets are used.
+Network errors, 5xx and 429 responses are retried with exponential backoff,
+other failures and deliveries out of attempts are moved to the dead-letter state.
+*/
+func AttemptDelivery(id string) {
+	if _, _, err := attemptDelivery(id); err != nil && !errors.Is(err, firestore.ErrDeliveryNotDue) {
+		log.Printf("Could not claim webhook delivery %s: %v", id, err)
+	}
+}
+
+// attemptDelivery makes an attempt at a due delivery and returns the logged attempt and the updated delivery.
+func attemptDelivery(id string) (*models.DeliveryAttempt, *models.WebhookDelivery, error) {
+	delivery, err := firestore.ClaimDelivery(id, time.Now(), constants.DeliveryLease)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	delivery.Attempts++
+	start := time.Now()
+	resp, err := deliver(delivery)
+	attempt := logDeliveryAttempt(delivery, resp, time.Since(start), err)
+	delivery.LastStatusCode = resp.statusCode
+
+	switch {
+	case err == nil:
+		delivery.Status = constants.DeliveryDelivered
+		delivery.LastError = ""
+	case retryable(resp.statusCode, err) && delivery.Attempts < constants.MaxDeliveryAttempts:
+		delivery.LastError = err.Error()
+		// A Retry-After longer than the backoff is honoured, up to the longest backoff
+		delivery.NextAttempt = time.Now().Add(min(max(deliveryBackoff(delivery.Attempts), resp.retryAfter), constants.DeliveryBackoffMax))
+	default:
+		delivery.Status = constants.DeliveryDead
+		delivery.LastError = err.Error()
+		log.Printf("Webhook delivery %s dead-lettered after %d attempts: %v", id, delivery.Attempts, err)
+	}
+
+	if err := firestore.SaveDelivery(delivery); err != nil {
+		log.Printf("Could not update webhook delivery %s: %v", id, err)
+	}
+	return attempt, delivery, nil
+}
+
+// deliver makes a single attempt at a delivery. A nil error means the receiver accepted it.
+func deliver(delivery *models.WebhookDelivery) (webhookResponse, error) {
+	entry, err := getWebhook(delivery.WebhookID)
+	if err != nil {
+		return webhookResponse{}, err
+	}
+
+	resp, err := sendWebhookNotification(*entry, []byte(delivery.Payload))
+	if err != nil {
+		return resp, err
+	}
+	if resp.statusCode < 200 || resp.statusCode > 299 {
+		return resp, fmt.Errorf(errorMessages.WebhookRejected, resp.statusCode)
+	}
+	return resp, nil
+}
+
+// getWebhook loads a webhook registration. Returns ErrWebhookNotFound if it does not exist;
+// other errors, e.g. while Firestore is unavailable, are returned as they are so the delivery is retried.
+func getWebhook(id string) (*models.WebhookRegistration, error) {
+	doc, err := firestore.Client.Collection("notifications").Doc(id).Get(context.Background())
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entry models.WebhookRegistration
+	if err := doc.DataTo(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// retryable reports whether a failed attempt may succeed later: network errors, server errors and rate limiting.
+// Deleted webhooks and blocked addresses are not retried.
+func retryable(statusCode int, err error) bool {
+	if statusCode == 0 {
+		return !errors.Is(err, ErrWebhookNotFound) && !errors.Is(err, ErrBlockedAddress)
+	}
+	return statusCode >= 500 || statusCode == http.StatusTooManyRequests
+}
+
+// deliveryBackoff returns the wait before the next attempt, doubling per attempt with up to 50% random jitter.
+func deliveryBackoff(attempts int) time.Duration {
+	backoff := constants.DeliveryBackoffMax
+	if attempts < 32 {
+		backoff = min(constants.DeliveryBackoffBase<<(attempts-1), constants.DeliveryBackoffMax)
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// StartDeliveryWorker attempts due deliveries at the given interval. It is meant to run in its own goroutine.
+func StartDeliveryWorker(interval time.Duration) {
+	for {
+		ids, err := firestore.DueDeliveries(time.Now())
+		if err != nil {
+			log.Printf("Failed to load due webhook deliveries: %v", err)
+		}
+		for _, id := range ids {
+			AttemptDelivery(id)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// RedriveDelivery puts a dead-lettered delivery back in the queue with a fresh set of attempts.
+func RedriveDelivery(id string) (*models.WebhookDelivery, error) {
+	delivery, err := firestore.GetDelivery(id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Status != constants.DeliveryDead {
+		return nil, ErrDeliveryNotDead
+	}
+
+	delivery.Status = constants.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttempt = time.Now()
+	if err := firestore.SaveDelivery(delivery); err != nil {
+		return nil, err
+	}
+
+	go AttemptDelivery(id)
+	return delivery, nil
+}
+
+// RedriveDeadDeliveries re-drives all dead-lettered deliveries, optionally only those of one webhook.
+// Returns the IDs of the re-driven deliveries.
+func RedriveDeadDeliveries(webhookID string) ([]string, error) {
+	dead, err := firestore.ListDeliveries(constants.DeliveryDead, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, delivery := range dead {
+		if _, err := RedriveDelivery(delivery.ID); err != nil {
+			log.Printf("Could not re-drive webhook delivery %s: %v", delivery.ID, err)
+			continue
+		}
+		ids = append(ids, delivery.ID)
+	}
+	return ids, nil
+}
+
+/*
+TestWebhook sends a synthetic event of the webhook's type through the delivery queue and waits for the first attempt.
+The test delivery is signed and, if the attempt fails with a retryable error, retried like any other delivery.
+*/
+func TestWebhook(webhookID string) (*models.WebhookTestResult, error) {
+	entry, err := getWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The first subscribed event and country; wildcard subscriptions get a REGISTER event
+	event := constants.EventRegister
+	if events := webhookEvents(*entry); len(events) > 0 && events[0] != constants.EventWildcard {
+		event = events[0]
+	}
+	country := ""
+	if countries := webhookCountries(*entry); len(countries) > 0 {
+		country = countries[0]
+	}
+
+	now := time.Now()
+	payload := models.WebhookPayload{
+		ID:        webhookID,
+		Country:   country,
+		Event:     event,
+		Time:      now.Format("20060102 15:04"),
+		Timestamp: now,
+		Test:      true,
+	}
+	id, err := queueDelivery(webhookID, event, country, payload)
+	if err != nil {
+		return nil, err
+	}
+	attempt, delivery, err := attemptDelivery(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WebhookTestResult{
+		DeliveryID:     id,
+		Event:          event,
+		Success:        attempt.Success,
+		StatusCode:     attempt.StatusCode,
+		LatencyMs:      attempt.LatencyMs,
+		ResponseBody:   attempt.ResponseBody,
+		Error:          attempt.Error,
+		DeliveryStatus: delivery.Status,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/constants/errorMessages"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"strings"
+	"syscall"
+)
+
+var ErrBlockedAddress = errors.New(errorMessages.BlockedWebhookAddress)
+
+// webhookClient sends webhook deliveries. Its dialer refuses addresses that webhooks may not reach, see guardDial.
+var webhookClient = &http.Client{
+	Timeout: constants.WebhookTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: constants.WebhookTimeout,
+			Control: guardDial,
+		}).DialContext,
+		TLSHandshakeTimeout: constants.WebhookTimeout,
+	},
+}
+
+/*
+guardDial stops webhook deliveries from reaching the service's own network.
+It runs after the host name is resolved, so names pointing at internal addresses are caught as well.
+Loopback, private, link-local (including cloud metadata endpoints) and unspecified addresses are refused
+unless WEBHOOK_ALLOWED_NETWORKS lists them.
+*/
+func guardDial(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+	addr := addrPort.Addr().Unmap()
+	if !blockedAddress(addr) {
+		return nil
+	}
+	for _, allowed := range allowedNetworks() {
+		if allowed.Contains(addr) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrBlockedAddress, addr)
+}
+
+// blockedNetworks are ranges webhooks may not reach by default that netip has no predicate for:
+// "this network" and the shared address space of carrier-grade NAT, which holds some cloud metadata
+// endpoints (e.g. 100.100.100.200) and internal load balancers.
+var blockedNetworks = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// blockedAddress reports whether the address belongs to a range webhooks may not reach by default.
+func blockedAddress(addr netip.Addr) bool {
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsUnspecified() {
+		return true
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedNetworks parses WEBHOOK_ALLOWED_NETWORKS, a comma-separated list of CIDR prefixes and single addresses.
+func allowedNetworks() []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, entry := range strings.Split(os.Getenv(constants.EnvWebhookAllowedNetworks), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		log.Printf("Ignoring invalid entry %q in %s", entry, constants.EnvWebhookAllowedNetworks)
+	}
+	return prefixes
+}
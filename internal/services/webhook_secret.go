@@ -0,0 +1,55 @@
+package services
+
+import (
+	"Country-Dashboard-Service/constants"
+	"Country-Dashboard-Service/internal/models"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// GenerateWebhookSecret returns a new random secret for signing webhook deliveries.
+func GenerateWebhookSecret() (string, error) {
+	key := make([]byte, constants.WebhookSecretBytes)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return constants.WebhookSecretPrefix + hex.EncodeToString(key), nil
+}
+
+// RotateWebhookSecret replaces the secret of a webhook. The old secret keeps signing deliveries
+// alongside the new one until the grace period ends, so receivers can switch without missing events.
+// Secrets retired by earlier rotations keep their own grace periods; only expired ones are dropped.
+func RotateWebhookSecret(entry *models.WebhookRegistration, secret string, grace time.Duration) error {
+	if secret == "" {
+		var err error
+		if secret, err = GenerateWebhookSecret(); err != nil {
+			return err
+		}
+	}
+	now := time.Now()
+	retired := make([]models.RetiredSecret, 0, len(entry.PreviousSecrets)+1)
+	for _, previous := range entry.PreviousSecrets {
+		if now.Before(previous.Expiry) {
+			retired = append(retired, previous)
+		}
+	}
+	if entry.Secret != "" {
+		retired = append(retired, models.RetiredSecret{Secret: entry.Secret, Expiry: now.Add(grace)})
+	}
+	entry.PreviousSecrets = retired
+	entry.Secret = secret
+	return nil
+}
+
+// activeSecrets returns the secrets a delivery is signed with: the current one and
+// the ones retired by rotations whose grace periods have not ended.
+func activeSecrets(entry *models.WebhookRegistration, now time.Time) []string {
+	secrets := []string{entry.Secret}
+	for _, previous := range entry.PreviousSecrets {
+		if now.Before(previous.Expiry) {
+			secrets = append(secrets, previous.Secret)
+		}
+	}
+	return secrets
+}
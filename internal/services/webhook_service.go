@@ -1,71 +1,203 @@
 package services
 
 import (
+	"Country-Dashboard-Service/constants"
 	"Country-Dashboard-Service/constants/errorMessages"
 	"Country-Dashboard-Service/internal/firestore"
 	"Country-Dashboard-Service/internal/models"
+	"Country-Dashboard-Service/pkg/webhooksig"
 	"bytes"
-	"context"
-	"encoding/json"
+	"io"
 	"log"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // TriggerWebhookEvent finds all webhook registrations matching the given event and optionally the country
-// and sends a POST notification to the registered URL.
+// and queues a POST notification to the registered URL.
 func TriggerWebhookEvent(event string, country string) {
-	// Query webhooks where event equals the given event.
-	iter := firestore.Client.Collection("notifications").Where("event", "==", event).Documents(context.Background())
-	defer iter.Stop()
-
-	for {
-		doc, err := iter.Next()
-		if err != nil {
-			break
-		}
-		var entry models.WebhookRegistration
-		if err = doc.DataTo(&entry); err != nil {
-			log.Printf(errorMessages.FailedWebhookDeserialization, err)
+	PublishWebhookEvent(event, country, models.WebhookEventData{})
+}
+
+// PublishWebhookEvent triggers the webhooks for an event like TriggerWebhookEvent,
+// with the registration snapshot, changes and dashboard of the event in the payload.
+func PublishWebhookEvent(event string, country string, data models.WebhookEventData) {
+	PublishWebhookEvents(event, []string{country}, data)
+}
+
+// PublishWebhookEvents triggers the webhooks for an event once per country, with the country in the payload.
+// The subscriptions are looked up once for all countries.
+func PublishWebhookEvents(event string, countries []string, data models.WebhookEventData) {
+	entries, err := firestore.WebhooksForEvent(event)
+	if err != nil {
+		log.Printf("Could not look up webhooks for %s: %v", event, err)
+		return
+	}
+
+	for _, country := range countries {
+		publishToWebhooks(matchingWebhooks(entries, country), event, country, data)
+	}
+}
+
+// PublishInvokeEvents triggers the INVOKE webhooks for every country of a dashboard.
+// The subscriptions are looked up once, and a country's invocation counter is only updated
+// when a subscription notified every n-th invocation covers it.
+func PublishInvokeEvents(countries []string, data models.WebhookEventData) {
+	entries, err := firestore.WebhooksForEvent(constants.EventInvoke)
+	if err != nil {
+		log.Printf("Could not look up webhooks for %s: %v", constants.EventInvoke, err)
+		return
+	}
+
+	for _, country := range countries {
+		matching := matchingWebhooks(entries, country)
+		if len(matching) == 0 {
 			continue
 		}
-		// If a country is specified in the registration and it doesn't match, skip.
-		if entry.Country != "" && entry.Country != country {
+		countryData := data
+		if slices.ContainsFunc(matching, func(entry models.WebhookRegistration) bool { return entry.InvokeEvery > 1 }) {
+			count, err := firestore.IncrementInvocations(country)
+			if err != nil {
+				log.Printf("Failed to count invocation of %s: %v", country, err)
+			}
+			countryData.Invocations = count
+		}
+		publishToWebhooks(matching, constants.EventInvoke, country, countryData)
+	}
+}
+
+// matchingWebhooks returns the webhooks whose country filter lets an event for any of the countries through.
+func matchingWebhooks(entries []models.WebhookRegistration, countries ...string) []models.WebhookRegistration {
+	var matching []models.WebhookRegistration
+	for _, entry := range entries {
+		if webhookMatchesCountry(entry, countries...) {
+			matching = append(matching, entry)
+		}
+	}
+	return matching
+}
+
+// publishToWebhooks queues a delivery of the event to each of the webhooks.
+func publishToWebhooks(entries []models.WebhookRegistration, event string, country string, data models.WebhookEventData) {
+	now := time.Now()
+	for _, entry := range entries {
+		// Subscriptions counting invocations only hear of every n-th INVOKE of the country
+		if event == constants.EventInvoke && !invocationDue(entry, data.Invocations) {
 			continue
 		}
 		// Prep payload.
-		payload := map[string]string{
-			"id":      entry.ID,
-			"country": country,
-			"event":   event,
-			"time":    time.Now().Format("20060102 15:04"),
+		payload := models.WebhookPayload{
+			ID:           entry.ID,
+			Country:      country,
+			Event:        event,
+			Time:         now.Format("20060102 15:04"),
+			Timestamp:    now,
+			Registration: data.Registration,
+			Changes:      data.Changes,
+			Invocations:  data.Invocations,
+		}
+		if data.Registration != nil {
+			payload.RegistrationID = data.Registration.ID
+		}
+		if entry.IncludeDashboard {
+			payload.Dashboard = data.Dashboard
+		}
+		// Queue the webhook invocation; it is retried until it succeeds or is dead-lettered.
+		if err := enqueueDelivery(entry.ID, event, country, payload); err != nil {
+			log.Printf(errorMessages.WebhookSendError, entry.URL, err)
 		}
-		// Send the webhook invocation.
-		go sendWebhookNotification(entry.URL, payload)
 	}
 }
 
-// sendWebhookNotification sends a POST request with the payload to the provided URL.
-func sendWebhookNotification(url string, payload map[string]string) {
-	data, err := json.Marshal(payload)
-	if err != nil {
-		log.Printf(errorMessages.WebhookPayloadMarshallingError, err)
-		return
+// webhookMatchesCountry reports whether a webhook's country filter lets the event for any of the countries through.
+// The filter may use any of the equivalent ISO codes (e.g. NO, NOR or 578), and no filter matches all countries.
+func webhookMatchesCountry(entry models.WebhookRegistration, countries ...string) bool {
+	filter := webhookCountries(entry)
+	if len(filter) == 0 {
+		return true
+	}
+	for _, c := range filter {
+		for _, country := range countries {
+			if SameCountry(c, country) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// invocationDue reports whether an INVOKE with the given invocation count of the country is notified to the webhook.
+// With invokeEvery set, the count must be a multiple of it; an unknown count of 0 is never one.
+func invocationDue(entry models.WebhookRegistration, count int64) bool {
+	if entry.InvokeEvery <= 1 {
+		return true
+	}
+	return count > 0 && count%int64(entry.InvokeEvery) == 0
+}
+
+// webhookCountries returns the country filter of a webhook, from either the list or the single country field.
+func webhookCountries(entry models.WebhookRegistration) []string {
+	if entry.Country == "" {
+		return entry.Countries
+	}
+	return append([]string{entry.Country}, entry.Countries...)
+}
+
+// webhookEvents returns the events a webhook subscribes to, from either the list or the single event field.
+func webhookEvents(entry models.WebhookRegistration) []string {
+	if entry.Event == "" {
+		return entry.Events
 	}
+	return append([]string{entry.Event}, entry.Events...)
+}
+
+// webhookResponse is what a receiver answered to a delivery.
+type webhookResponse struct {
+	statusCode int
+	retryAfter time.Duration // Wait requested by a Retry-After header, if any
+	body       string        // Start of the response body
+}
+
+// sendWebhookNotification sends a POST request with the payload to the webhook's URL.
+// Deliveries are signed with the webhook's active secrets, see package webhooksig.
+func sendWebhookNotification(entry models.WebhookRegistration, data []byte) (webhookResponse, error) {
+	url := entry.URL
 	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(data))
 	if err != nil {
 		log.Printf(errorMessages.WebhookRequestCreationError, err)
-		return
+		return webhookResponse{}, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-	resp, err := client.Do(req)
+	webhooksig.SetHeaders(req.Header, time.Now(), data, activeSecrets(&entry, time.Now())...)
+	resp, err := webhookClient.Do(req)
 	if err != nil {
 		log.Printf(errorMessages.WebhookSendError, url, err)
-		return
+		return webhookResponse{}, err
 	}
 	defer resp.Body.Close()
 	log.Printf("Webhook sent to %s with status code %d", url, resp.StatusCode)
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, constants.WebhookResponseSnippet))
+	return webhookResponse{
+		statusCode: resp.StatusCode,
+		retryAfter: retryAfter(resp.Header.Get("Retry-After")),
+		body:       strings.ToValidUTF8(string(body), ""),
+	}, nil
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date. Returns 0 if absent or invalid.
+func retryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at)
+	}
+	return 0
 }
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"Country-Dashboard-Service/internal/models"
+	"testing"
+)
+
+func TestWebhookMatchesCountry(t *testing.T) {
+	useTestCatalogue(t, nordicCatalogue)
+
+	tests := []struct {
+		name      string
+		entry     models.WebhookRegistration
+		countries []string
+		want      bool
+	}{
+		{"No filter matches all", models.WebhookRegistration{}, []string{"SE"}, true},
+		{"Single country", models.WebhookRegistration{Country: "NO"}, []string{"NO"}, true},
+		{"Alpha-3 filter", models.WebhookRegistration{Country: "NOR"}, []string{"NO"}, true},
+		{"Numeric filter", models.WebhookRegistration{Country: "578"}, []string{"NO"}, true},
+		{"Other country", models.WebhookRegistration{Country: "NO"}, []string{"SE"}, false},
+		{"Country list", models.WebhookRegistration{Countries: []string{"FIN", "752"}}, []string{"SE"}, true},
+		{"Single country and list", models.WebhookRegistration{Country: "FI", Countries: []string{"NO"}}, []string{"NOR"}, true},
+		{"Any of several countries", models.WebhookRegistration{Country: "SWE"}, []string{"NO", "SE"}, true},
+		{"None of several countries", models.WebhookRegistration{Country: "FI"}, []string{"NO", "SE"}, false},
+		{"Filter without countries", models.WebhookRegistration{Country: "NO"}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := webhookMatchesCountry(tt.entry, tt.countries...); got != tt.want {
+				t.Errorf("Expected %v for %v, got %v", tt.want, tt.countries, got)
+			}
+		})
+	}
+}
@@ -1,24 +1,133 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
 // CustomTime wraps time.Time to allow custom JSON formatting.
+// By default it is written in the legacy layout in Europe/Oslo, use WithSettings to change the zone or format.
 type CustomTime struct {
 	time.Time
+	location *time.Location // Zone the time is written in, Europe/Oslo if nil
+	format   string         // TimeFormatLegacy or TimeFormatRFC3339
 }
 
 // Layout with timezone – adjust as needed
 const timeLayout = "2006-01-02 15:04:05 MST"
 
-func (ct CustomTime) MarshalJSON() ([]byte, error) {
-	// Convert to Europe/Oslo timezone
-	loc, err := time.LoadLocation("Europe/Oslo")
+// Supported timestamp formats
+const (
+	TimeFormatLegacy  = "legacy"  // "2006-01-02 15:04:05 MST", always in Europe/Oslo
+	TimeFormatRFC3339 = "rfc3339" // "2006-01-02T15:04:05+01:00"
+)
+
+// DefaultTimezone is the zone timestamps are written in unless another is requested.
+const DefaultTimezone = "Europe/Oslo"
+
+// Errors returned for invalid time settings and timestamps
+var (
+	ErrUnknownTimezone   = errors.New("unknown timezone")
+	ErrUnknownTimeFormat = errors.New("unknown time format")
+	ErrLegacyTimezone    = errors.New("the legacy time format is only available in " + DefaultTimezone)
+	ErrInvalidTimestamp  = errors.New("invalid timestamp")
+)
+
+// TimeSettings holds the zone and format timestamps are written in.
+type TimeSettings struct {
+	Location *time.Location
+	Format   string
+}
+
+// ParseTimeSettings validates a timezone name and format. Both are optional.
+// A timezone without a format gives RFC 3339, as the legacy layout is tied to Europe/Oslo.
+func ParseTimeSettings(tz, format string) (TimeSettings, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	tz = strings.TrimSpace(tz)
+
+	switch format {
+	case "":
+		format = TimeFormatLegacy
+		if tz != "" {
+			format = TimeFormatRFC3339
+		}
+	case TimeFormatLegacy, TimeFormatRFC3339:
+	default:
+		return TimeSettings{}, fmt.Errorf("%w: %s", ErrUnknownTimeFormat, format)
+	}
+
+	if tz == "" {
+		tz = DefaultTimezone
+	}
+	loc, err := time.LoadLocation(tz)
 	if err != nil {
-		return nil, err
+		return TimeSettings{}, fmt.Errorf("%w: %s", ErrUnknownTimezone, tz)
+	}
+	if format == TimeFormatLegacy && loc.String() != DefaultTimezone {
+		return TimeSettings{}, ErrLegacyTimezone
 	}
-	formatted := ct.Time.In(loc).Format(timeLayout)
+	return TimeSettings{Location: loc, Format: format}, nil
+}
+
+// WithSettings returns a copy of the time that is written in the given zone and format.
+func (ct CustomTime) WithSettings(settings TimeSettings) CustomTime {
+	ct.location = settings.Location
+	ct.format = settings.Format
+	return ct
+}
+
+func (ct CustomTime) MarshalJSON() ([]byte, error) {
+	loc := ct.location
+	if loc == nil {
+		// Convert to Europe/Oslo timezone
+		var err error
+		if loc, err = time.LoadLocation(DefaultTimezone); err != nil {
+			return nil, err
+		}
+	}
+
+	layout := timeLayout
+	if ct.format == TimeFormatRFC3339 {
+		layout = time.RFC3339
+	}
+	formatted := ct.Time.In(loc).Format(layout)
 	return []byte(fmt.Sprintf(`"%s"`, formatted)), nil
 }
+
+// UnmarshalJSON parses timestamps in every format MarshalJSON writes: RFC 3339, and the legacy layout in Europe/Oslo.
+func (ct *CustomTime) UnmarshalJSON(data []byte) error {
+	raw := strings.TrimSpace(string(data))
+	if raw == "null" || raw == `""` {
+		ct.Time = time.Time{}
+		return nil
+	}
+	value, ok := strings.CutPrefix(raw, `"`)
+	if value, ok = strings.CutSuffix(value, `"`); !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidTimestamp, raw)
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		ct.Time = t
+		ct.location = t.Location()
+		ct.format = TimeFormatRFC3339
+		return nil
+	}
+
+	loc, err := time.LoadLocation(DefaultTimezone)
+	if err != nil {
+		return err
+	}
+	t, err := time.ParseInLocation(timeLayout, value, loc)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidTimestamp, value)
+	}
+	// An abbreviation that is not used in Europe/Oslo would be parsed with a made-up zero offset
+	if zone, _ := t.Zone(); t.Location() != loc && zone != "UTC" {
+		return fmt.Errorf("%w: unknown zone %s", ErrInvalidTimestamp, zone)
+	}
+	ct.Time = t
+	ct.format = TimeFormatLegacy
+	return nil
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PreferredLanguages returns the primary language subtags of the request's Accept-Language header,
+// most preferred first. "nb-NO,nb;q=0.9,en;q=0.8" gives ["nb", "en"].
+func PreferredLanguages(r *http.Request) []string {
+	type weighted struct {
+		lang    string
+		quality float64
+	}
+	var accepted []weighted
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if lang == "" || lang == "*" || seen[lang] {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		seen[lang] = true
+		accepted = append(accepted, weighted{lang, quality})
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool { return accepted[i].quality > accepted[j].quality })
+	languages := make([]string, 0, len(accepted))
+	for _, a := range accepted {
+		languages = append(languages, a.lang)
+	}
+	return languages
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"math"
+	"time"
+)
+
+// SolarDay holds sunrise, sunset and day length for a date at a location.
+type SolarDay struct {
+	Sunrise    time.Time
+	Sunset     time.Time
+	DayLength  time.Duration
+	PolarDay   bool // The sun does not set on this date
+	PolarNight bool // The sun does not rise on this date
+}
+
+// Constants for the sunrise equation, see https://en.wikipedia.org/wiki/Sunrise_equation
+const (
+	julianEpoch2000 = 2451545.0  // Julian date of 2000-01-01 12:00 UTC
+	julianUnixEpoch = 2440587.5  // Julian date of 1970-01-01 00:00 UTC
+	earthTilt       = 23.4397    // Axial tilt of the earth in degrees
+	sunAltitude     = -0.833     // Altitude of the sun's centre at sunrise/sunset, corrected for refraction
+	secondsPerDay   = 86400.0    // Seconds in a day
+	perihelion      = 102.9372   // Argument of the perihelion in degrees
+	meanAnomalyRate = 0.98560028 // Degrees the mean anomaly moves per day
+	meanAnomalyBase = 357.5291   // Mean anomaly at the J2000 epoch
+	julianLeapFix   = 0.0008     // Correction for leap seconds and terrestrial time
+)
+
+// ComputeSolarDay calculates sunrise and sunset in UTC at the given coordinates for the date of t.
+// Latitude is positive north and longitude is positive east.
+func ComputeSolarDay(lat, lon float64, t time.Time) SolarDay {
+	// Current Julian day number
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	jd := float64(midnight.Unix())/secondsPerDay + julianUnixEpoch
+	n := math.Ceil(jd - julianEpoch2000 + julianLeapFix)
+
+	// Mean solar time, solar mean anomaly and equation of the centre
+	meanSolar := n - lon/360
+	m := math.Mod(meanAnomalyBase+meanAnomalyRate*meanSolar, 360)
+	mRad := degToRad(m)
+	c := 1.9148*math.Sin(mRad) + 0.02*math.Sin(2*mRad) + 0.0003*math.Sin(3*mRad)
+
+	// Ecliptic longitude and solar transit
+	lambda := math.Mod(m+c+180+perihelion, 360)
+	lambdaRad := degToRad(lambda)
+	transit := julianEpoch2000 + meanSolar + 0.0053*math.Sin(mRad) - 0.0069*math.Sin(2*lambdaRad)
+
+	// Declination of the sun and hour angle
+	sinDecl := math.Sin(lambdaRad) * math.Sin(degToRad(earthTilt))
+	cosDecl := math.Cos(math.Asin(sinDecl))
+	latRad := degToRad(lat)
+	cosHourAngle := (math.Sin(degToRad(sunAltitude)) - math.Sin(latRad)*sinDecl) / (math.Cos(latRad) * cosDecl)
+
+	switch {
+	case cosHourAngle < -1:
+		return SolarDay{PolarDay: true, DayLength: 24 * time.Hour}
+	case cosHourAngle > 1:
+		return SolarDay{PolarNight: true}
+	}
+
+	hourAngle := radToDeg(math.Acos(cosHourAngle))
+	sunrise := julianToTime(transit - hourAngle/360)
+	sunset := julianToTime(transit + hourAngle/360)
+
+	return SolarDay{
+		Sunrise:   sunrise,
+		Sunset:    sunset,
+		DayLength: sunset.Sub(sunrise),
+	}
+}
+
+// julianToTime converts a Julian date to a UTC time, rounded to the second.
+func julianToTime(jd float64) time.Time {
+	seconds := (jd - julianUnixEpoch) * secondsPerDay
+	return time.Unix(int64(math.Round(seconds)), 0).UTC()
+}
+
+func degToRad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func radToDeg(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
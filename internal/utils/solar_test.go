@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeSolarDay(t *testing.T) {
+	midsummer := time.Date(2024, time.June, 21, 12, 0, 0, 0, time.UTC)
+	midwinter := time.Date(2024, time.December, 21, 12, 0, 0, 0, time.UTC)
+	equinox := time.Date(2024, time.March, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		lat, lon   float64
+		date       time.Time
+		polarDay   bool
+		polarNight bool
+		minLength  time.Duration
+		maxLength  time.Duration
+	}{
+		{"Tromsø midsummer", 69.65, 18.96, midsummer, true, false, 24 * time.Hour, 24 * time.Hour},
+		{"Tromsø midwinter", 69.65, 18.96, midwinter, false, true, 0, 0},
+		{"McMurdo midsummer", -77.85, 166.67, midsummer, false, true, 0, 0},
+		{"Oslo midsummer", 59.91, 10.75, midsummer, false, false, 18*time.Hour + 30*time.Minute, 19 * time.Hour},
+		{"Oslo midwinter", 59.91, 10.75, midwinter, false, false, 5*time.Hour + 45*time.Minute, 6*time.Hour + 15*time.Minute},
+		{"Equator equinox", 0, 0, equinox, false, false, 12 * time.Hour, 12*time.Hour + 15*time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day := ComputeSolarDay(tt.lat, tt.lon, tt.date)
+			if day.PolarDay != tt.polarDay || day.PolarNight != tt.polarNight {
+				t.Fatalf("Expected polar day %v and night %v, got %+v", tt.polarDay, tt.polarNight, day)
+			}
+			if day.DayLength < tt.minLength || day.DayLength > tt.maxLength {
+				t.Errorf("Expected a day length between %v and %v, got %v", tt.minLength, tt.maxLength, day.DayLength)
+			}
+			if !tt.polarDay && !tt.polarNight {
+				if !day.Sunrise.Before(day.Sunset) || day.Sunrise.YearDay() != tt.date.YearDay() {
+					t.Errorf("Expected sunrise before sunset on the same date, got %v and %v", day.Sunrise, day.Sunset)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseUTCOffset parses a REST Countries timezone like "UTC+05:30" or "UTC" into an offset in seconds.
+func ParseUTCOffset(tz string) (int, bool) {
+	// Some entries use the Unicode minus sign
+	tz = strings.ReplaceAll(strings.TrimSpace(tz), "−", "-")
+	if tz == "UTC" {
+		return 0, true
+	}
+	if !strings.HasPrefix(tz, "UTC") || len(tz) < 5 {
+		return 0, false
+	}
+
+	sign := 1
+	switch tz[3] {
+	case '+':
+	case '-':
+		sign = -1
+	default:
+		return 0, false
+	}
+
+	hours, minutes, _ := strings.Cut(tz[4:], ":")
+	h, err := strconv.Atoi(hours)
+	if err != nil {
+		return 0, false
+	}
+	m := 0
+	if minutes != "" {
+		if m, err = strconv.Atoi(minutes); err != nil {
+			return 0, false
+		}
+	}
+	return sign * (h*3600 + m*60), true
+}
+
+// CapitalTimezone returns the IANA timezone of a country's capital, so local times follow daylight saving time.
+// Countries missing from the table fall back to the REST Countries offset closest to the capital, see ClosestTimezone.
+func CapitalTimezone(isoCode string, timezones []string, lon float64) (string, *time.Location, bool) {
+	if name, ok := capitalZones[strings.ToUpper(isoCode)]; ok {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return name, loc, true
+		}
+	}
+	return ClosestTimezone(timezones, lon)
+}
+
+// ClosestTimezone picks the timezone whose offset best matches the solar time at the given longitude.
+// Countries spanning several timezones (e.g. Russia) otherwise have no way to tell where the capital is.
+func ClosestTimezone(timezones []string, lon float64) (string, *time.Location, bool) {
+	solarOffset := lon / 15 * 3600
+	bestName, bestOffset, found := "", 0, false
+	for _, tz := range timezones {
+		offset, ok := ParseUTCOffset(tz)
+		if !ok {
+			continue
+		}
+		if !found || math.Abs(float64(offset)-solarOffset) < math.Abs(float64(bestOffset)-solarOffset) {
+			bestName, bestOffset, found = tz, offset, true
+		}
+	}
+	if !found {
+		return "", nil, false
+	}
+	return bestName, time.FixedZone(bestName, bestOffset), true
+}
+
+// FormatDuration formats a duration as hours and minutes, e.g. "14h32m".
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h := int(d.Hours())
+	m := int(d.Minutes()) - h*60
+	return strconv.Itoa(h) + "h" + strconv.Itoa(m) + "m"
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"testing"
+	_ "time/tzdata" // Capital zones are loaded by name, also where the system has no zoneinfo
+)
+
+func TestCapitalTimezone(t *testing.T) {
+	russia := []string{"UTC+03:00", "UTC+04:00", "UTC+05:00", "UTC+06:00", "UTC+07:00", "UTC+08:00", "UTC+09:00", "UTC+10:00", "UTC+11:00", "UTC+12:00"}
+
+	tests := []struct {
+		name      string
+		isoCode   string
+		timezones []string
+		lon       float64
+		want      string
+		found     bool
+	}{
+		{"Capital zone", "NO", []string{"UTC+01:00"}, 10.75, "Europe/Oslo", true},
+		{"Lower-case code", "se", []string{"UTC+01:00"}, 18.07, "Europe/Stockholm", true},
+		{"Capital in the west of a wide country", "RU", russia, 37.62, "Europe/Moscow", true},
+		{"Capital of a country with several zones", "AU", []string{"UTC+05:00", "UTC+10:00"}, 149.13, "Australia/Sydney", true},
+		{"Unknown country falls back to the closest offset", "ZZ", []string{"UTC+03:00", "UTC+05:00"}, 80, "UTC+05:00", true},
+		{"Unicode minus", "ZZ", []string{"UTC−05:00", "UTC"}, -74, "UTC−05:00", true},
+		{"Nothing to go on", "ZZ", []string{"bogus"}, 0, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, loc, found := CapitalTimezone(tt.isoCode, tt.timezones, tt.lon)
+			if name != tt.want || found != tt.found {
+				t.Fatalf("Expected %q (found %v), got %q (found %v)", tt.want, tt.found, name, found)
+			}
+			if found && loc == nil {
+				t.Errorf("Expected a location for %q", name)
+			}
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package main
 
 import (
+	"Country-Dashboard-Service/constants"
 	"Country-Dashboard-Service/internal/firestore"
 	"Country-Dashboard-Service/internal/server"
 	serverWebhook "Country-Dashboard-Service/internal/serverwebhook"
+	"Country-Dashboard-Service/internal/services"
+	"log"
+	"os"
+	_ "time/tzdata" // Timezone database for capitals' local times, the runtime image has none
 )
 
 /*
@@ -15,6 +20,28 @@ func main() {
 	// Initialize Firestore before processing any requests.
 	firestore.InitFirestore()
 
+	// Load extra country aliases (e.g. "Blighty" -> GB) if a dictionary file is configured.
+	if path := os.Getenv(constants.EnvCountryAliasesFile); path != "" {
+		if err := services.LoadCountryAliases(path); err != nil {
+			log.Printf("Could not load country aliases from %s: %v", path, err)
+		}
+	}
+
+	// Build the country catalogue and keep it fresh in the background.
+	go services.StartCatalogueRefresher(constants.CatalogueRefreshInterval)
+
+	// Retry queued webhook deliveries that failed or were interrupted.
+	go services.StartDeliveryWorker(constants.DeliveryPollInterval)
+
+	// Keep the webhook delivery log within its retention period.
+	go services.StartDeliveryLogPruner(constants.DeliveryLogPruneInterval)
+
+	// Notify weather alert subscribers when their conditions start or stop holding.
+	go services.StartWeatherAlertEvaluator(constants.WeatherAlertInterval)
+
+	// Notify exchange-rate subscribers when a rate moves more than their threshold.
+	go services.StartRateMonitor(constants.RateMonitorInterval)
+
 	// Create the primary server.
 	srv := server.NewServer(":8080")
 	// Start the primary server in a separate goroutine.
@@ -0,0 +1,127 @@
+/*
+Package webhooksig signs and verifies the webhook deliveries of the Country Dashboard Service.
+
+Every delivery carries two headers:
+
+	X-Webhook-Timestamp: 1744203242
+	X-Webhook-Signature: sha256=5257a869e7ecebeda32affa62cdca3fa51cad7e77a0e56ff536d0ce8e108d8bd
+
+The signature is the hex-encoded HMAC-SHA256 of the timestamp, a dot and the raw request body, keyed with the
+subscription's secret. While a secret is being rotated the header holds one signature per active secret, separated
+by commas, and a delivery is valid if any of them matches.
+
+A receiver written in Go can verify a delivery with:
+
+	body, err := webhooksig.VerifyRequest(r, webhooksig.DefaultTolerance, secret)
+	if err != nil {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+*/
+package webhooksig
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers set on every signed delivery
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+)
+
+// signaturePrefix precedes every signature in the signature header.
+const signaturePrefix = "sha256="
+
+// DefaultTolerance is how old a delivery may be before it is rejected as a possible replay.
+const DefaultTolerance = 5 * time.Minute
+
+// Errors returned when a delivery can't be verified
+var (
+	ErrMissingSignature  = errors.New("webhooksig: missing signature or timestamp header")
+	ErrInvalidTimestamp  = errors.New("webhooksig: invalid timestamp")
+	ErrTimestampExpired  = errors.New("webhooksig: timestamp outside the tolerance")
+	ErrSignatureMismatch = errors.New("webhooksig: no signature matches")
+)
+
+// Sign returns the hex-encoded HMAC-SHA256 of the timestamp and body, keyed with the secret.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SetHeaders signs the body with every secret and sets the timestamp and signature headers.
+// Empty secrets are skipped, and no headers are set if there are no secrets.
+func SetHeaders(header http.Header, timestamp time.Time, body []byte, secrets ...string) {
+	var signatures []string
+	for _, secret := range secrets {
+		if secret != "" {
+			signatures = append(signatures, signaturePrefix+Sign(secret, timestamp, body))
+		}
+	}
+	if len(signatures) == 0 {
+		return
+	}
+	header.Set(TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	header.Set(SignatureHeader, strings.Join(signatures, ","))
+}
+
+// Verify checks that the headers hold a signature of the body made with one of the secrets,
+// and that the timestamp is no further than tolerance from now. A tolerance of zero skips the timestamp check.
+func Verify(header http.Header, body []byte, tolerance time.Duration, secrets ...string) error {
+	rawTimestamp, rawSignatures := header.Get(TimestampHeader), header.Get(SignatureHeader)
+	if rawTimestamp == "" || rawSignatures == "" {
+		return ErrMissingSignature
+	}
+
+	unix, err := strconv.ParseInt(rawTimestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	timestamp := time.Unix(unix, 0)
+	if age := time.Since(timestamp); tolerance > 0 && (age > tolerance || age < -tolerance) {
+		return ErrTimestampExpired
+	}
+
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		expected := []byte(Sign(secret, timestamp, body))
+		for _, signature := range strings.Split(rawSignatures, ",") {
+			signature = strings.TrimPrefix(strings.TrimSpace(signature), signaturePrefix)
+			if hmac.Equal([]byte(signature), expected) {
+				return nil
+			}
+		}
+	}
+	return ErrSignatureMismatch
+}
+
+// VerifyRequest reads the body of a delivery and verifies it. The body is returned, and also
+// put back on the request so handlers can read it again.
+func VerifyRequest(r *http.Request, tolerance time.Duration, secrets ...string) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := Verify(r.Header, body, tolerance, secrets...); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
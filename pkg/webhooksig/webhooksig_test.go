@@ -0,0 +1,67 @@
+package webhooksig
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"event":"REGISTER"}`)
+	header := http.Header{}
+	SetHeaders(header, time.Now(), body, "secret")
+
+	if err := Verify(header, body, DefaultTolerance, "secret"); err != nil {
+		t.Errorf("Expected a valid signature, got %v", err)
+	}
+	if err := Verify(header, []byte(`{"event":"DELETE"}`), DefaultTolerance, "secret"); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("Expected a mismatch for a changed body, got %v", err)
+	}
+	if err := Verify(header, body, DefaultTolerance, "other"); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("Expected a mismatch for another secret, got %v", err)
+	}
+}
+
+func TestVerify_Rotation(t *testing.T) {
+	body := []byte(`{}`)
+	header := http.Header{}
+	SetHeaders(header, time.Now(), body, "new", "old")
+
+	// Receivers that still only know the old secret keep working during the grace period
+	for _, secret := range []string{"new", "old"} {
+		if err := Verify(header, body, DefaultTolerance, secret); err != nil {
+			t.Errorf("Expected %q to verify, got %v", secret, err)
+		}
+	}
+}
+
+func TestVerify_Replay(t *testing.T) {
+	body := []byte(`{}`)
+	header := http.Header{}
+	SetHeaders(header, time.Now().Add(-time.Hour), body, "secret")
+
+	if err := Verify(header, body, DefaultTolerance, "secret"); !errors.Is(err, ErrTimestampExpired) {
+		t.Errorf("Expected an expired timestamp, got %v", err)
+	}
+	if err := Verify(http.Header{}, body, DefaultTolerance, "secret"); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("Expected a missing signature, got %v", err)
+	}
+}
+
+func TestVerifyRequest_KeepsBody(t *testing.T) {
+	body := []byte(`{"event":"INVOKE"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	SetHeaders(req.Header, time.Now(), body, "secret")
+
+	if _, err := VerifyRequest(req, DefaultTolerance, "secret"); err != nil {
+		t.Fatalf("Expected a valid request, got %v", err)
+	}
+	again, _ := io.ReadAll(req.Body)
+	if !bytes.Equal(again, body) {
+		t.Errorf("Expected the body to be readable again, got %q", again)
+	}
+}